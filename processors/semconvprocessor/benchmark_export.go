@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// maxBenchmarkExportEntries bounds each list in the benchmark export; the most
+// frequent entries are kept
+const maxBenchmarkExportEntries = 10000
+
+// nameMapping is a span name together with the operation name it was mapped to
+type nameMapping struct {
+	original  string
+	operation string
+}
+
+// benchmarkExport is the JSON document written to benchmark_export_path
+type benchmarkExport struct {
+	SpanNames      []benchmarkNameCount    `json:"span_names"`
+	OperationNames []benchmarkNameCount    `json:"operation_names"`
+	Mappings       []benchmarkMappingCount `json:"mappings"`
+	Truncated      bool                    `json:"truncated"`
+}
+
+// benchmarkNameCount is a name and how often it was seen
+type benchmarkNameCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// benchmarkMappingCount is an original span name, its operation name and how often the pair was seen
+type benchmarkMappingCount struct {
+	Original  string `json:"original"`
+	Operation string `json:"operation"`
+	Count     int64  `json:"count"`
+}
+
+// hasBenchmarkCounts reports whether any span has been counted
+func (sp *semconvProcessor) hasBenchmarkCounts() bool {
+	sp.benchmarkMu.Lock()
+	defer sp.benchmarkMu.Unlock()
+	return len(sp.spanNameCount) > 0
+}
+
+// exportBenchmark writes the benchmark counts to benchmark_export_path
+func (sp *semconvProcessor) exportBenchmark() error {
+	sp.benchmarkMu.Lock()
+	export := benchmarkExport{
+		SpanNames:      sortedNameCounts(sp.spanNameCount),
+		OperationNames: sortedNameCounts(sp.operationCount),
+		Mappings:       make([]benchmarkMappingCount, 0, len(sp.nameMappingCount)),
+	}
+	for mapping, count := range sp.nameMappingCount {
+		export.Mappings = append(export.Mappings, benchmarkMappingCount{
+			Original:  mapping.original,
+			Operation: mapping.operation,
+			Count:     count,
+		})
+	}
+	sp.benchmarkMu.Unlock()
+
+	sort.Slice(export.Mappings, func(i, j int) bool {
+		a, b := export.Mappings[i], export.Mappings[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Original != b.Original {
+			return a.Original < b.Original
+		}
+		return a.Operation < b.Operation
+	})
+
+	if len(export.SpanNames) > maxBenchmarkExportEntries {
+		export.SpanNames = export.SpanNames[:maxBenchmarkExportEntries]
+		export.Truncated = true
+	}
+	if len(export.OperationNames) > maxBenchmarkExportEntries {
+		export.OperationNames = export.OperationNames[:maxBenchmarkExportEntries]
+		export.Truncated = true
+	}
+	if len(export.Mappings) > maxBenchmarkExportEntries {
+		export.Mappings = export.Mappings[:maxBenchmarkExportEntries]
+		export.Truncated = true
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode benchmark export: %w", err)
+	}
+	if err := os.WriteFile(sp.config.BenchmarkExportPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write benchmark export: %w", err)
+	}
+	return nil
+}
+
+// sortedNameCounts returns the counts ordered by count, most frequent first, then by name
+func sortedNameCounts(counts map[string]int64) []benchmarkNameCount {
+	result := make([]benchmarkNameCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, benchmarkNameCount{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
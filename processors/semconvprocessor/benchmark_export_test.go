@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestBenchmarkExport(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "benchmark.json")
+	cfg := &Config{
+		Enabled:             true,
+		Benchmark:           true,
+		BenchmarkExportPath: exportPath,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"GET /users/1", "GET /users/2", "GET /users/1", "health"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		if name != "health" {
+			span.Attributes().PutStr("http.request.method", "GET")
+			span.Attributes().PutStr("http.route", "/users/{id}")
+		}
+	}
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Nothing is written until shutdown
+	_, err = os.Stat(exportPath)
+	require.True(t, os.IsNotExist(err))
+	require.NoError(t, processor.shutdown(context.Background()))
+
+	data, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	var export benchmarkExport
+	require.NoError(t, json.Unmarshal(data, &export))
+
+	assert.Equal(t, []benchmarkNameCount{
+		{Name: "GET /users/1", Count: 2},
+		{Name: "GET /users/2", Count: 1},
+		{Name: "health", Count: 1},
+	}, export.SpanNames)
+	assert.Equal(t, []benchmarkNameCount{
+		{Name: "GET /users/{id}", Count: 3},
+	}, export.OperationNames)
+	assert.Equal(t, []benchmarkMappingCount{
+		{Original: "GET /users/1", Operation: "GET /users/{id}", Count: 2},
+		{Original: "GET /users/2", Operation: "GET /users/{id}", Count: 1},
+	}, export.Mappings)
+	assert.False(t, export.Truncated)
+}
+
+func TestBenchmarkExport_Truncated(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "benchmark.json")
+	cfg := &Config{
+		Enabled:             true,
+		Benchmark:           true,
+		BenchmarkExportPath: exportPath,
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	// The most frequent name survives the bound
+	processor.spanNameCount["frequent"] = 2
+	for i := 0; i < maxBenchmarkExportEntries; i++ {
+		processor.spanNameCount[fmt.Sprintf("name-%d", i)] = 1
+	}
+	require.NoError(t, processor.shutdown(context.Background()))
+
+	data, err := os.ReadFile(exportPath)
+	require.NoError(t, err)
+	var export benchmarkExport
+	require.NoError(t, json.Unmarshal(data, &export))
+	assert.True(t, export.Truncated)
+	require.Len(t, export.SpanNames, maxBenchmarkExportEntries)
+	assert.Equal(t, benchmarkNameCount{Name: "frequent", Count: 2}, export.SpanNames[0])
+}
+
+func TestBenchmarkExport_NothingCounted(t *testing.T) {
+	exportPath := filepath.Join(t.TempDir(), "benchmark.json")
+	cfg := &Config{
+		Enabled:             true,
+		Benchmark:           true,
+		BenchmarkExportPath: exportPath,
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	// A processor for another signal counts no spans and leaves the file alone
+	require.NoError(t, processor.shutdown(context.Background()))
+	_, err = os.Stat(exportPath)
+	assert.True(t, os.IsNotExist(err))
+}
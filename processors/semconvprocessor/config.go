@@ -6,8 +6,12 @@ package semconvprocessor
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"go.opentelemetry.io/collector/component"
 )
 
@@ -19,8 +23,61 @@ type Config struct {
 	// Benchmark enables cardinality metrics tracking
 	Benchmark bool `mapstructure:"benchmark"`
 	
+	// BenchmarkInterval flushes benchmark metrics periodically instead of after every batch.
+	// Zero (the default) keeps flushing after every batch.
+	BenchmarkInterval time.Duration `mapstructure:"benchmark_interval"`
+	
+	// RuleMatchWindow, when set, reports the rules that matched no span during each
+	// window of this length, to find stale rules (benchmark and audit modes)
+	RuleMatchWindow time.Duration `mapstructure:"rule_match_window"`
+	
+	// BenchmarkKeyAttribute is a resource attribute (e.g. service.name) used to report
+	// benchmark cardinality per value instead of globally across the batch
+	BenchmarkKeyAttribute string `mapstructure:"benchmark_key_attribute"`
+	
+	// BenchmarkExportPath, when set, writes the span names, operation names and the
+	// mapping between them, with counts, to this file as JSON on shutdown (benchmark only)
+	BenchmarkExportPath string `mapstructure:"benchmark_export_path"`
+	
+	// MetricsSampleRatio records the processing duration for only this fraction of
+	// batches to reduce overhead; counters stay exact. 0 (the default) records every batch
+	MetricsSampleRatio float64 `mapstructure:"metrics_sample_ratio"`
+	
+	// Signals limits processing to the listed signals (traces, metrics, logs, profiles).
+	// Signals not listed pass through unchanged. Empty (the default) processes all signals
+	Signals []string `mapstructure:"signals"`
+	
+	// ResourceAttributeAllowlist, when non-empty, removes every resource attribute
+	// not in the list from all signals
+	ResourceAttributeAllowlist []string `mapstructure:"resource_attribute_allowlist"`
+	
+	// SemconvVersion selects the embedded semantic convention registry that span attributes
+	// are checked against for the unknown attributes metric (e.g. "1.27"). Empty (the
+	// default) uses the newest embedded registry
+	SemconvVersion string `mapstructure:"semconv_version"`
+	
+	// MaxRegexInputBytes skips user-supplied regular expressions (rewrites, log rules, service_classification,
+	// RegexReplace, generic_name_pattern) on longer inputs, which are left unchanged
+	// and counted. 0 defaults to 16384; -1 disables the limit
+	MaxRegexInputBytes int `mapstructure:"max_regex_input_bytes"`
+	
+	// KnownAttributes extends the built-in semantic convention registry with span attribute
+	// keys that should not be reported as unknown (benchmark and audit modes)
+	KnownAttributes []string `mapstructure:"known_attributes"`
+	
 	// SpanProcessing defines rules for processing span names
 	SpanProcessing SpanProcessingConfig `mapstructure:"span_processing"`
+	
+	// LogProcessing defines rules deriving operation names from log bodies
+	LogProcessing LogProcessingConfig `mapstructure:"log_processing"`
+	
+	// SchemaMigration renames attributes of spans, metrics and logs, and metrics, to a target version
+	// of an OpenTelemetry schema file, based on the schema URL of the incoming data
+	SchemaMigration SchemaMigrationConfig `mapstructure:"schema_migration"`
+	
+	// ServiceClassification labels each resource, e.g. with a service tier, by matching
+	// its service.name against regular expressions
+	ServiceClassification ServiceClassificationConfig `mapstructure:"service_classification"`
 }
 
 // SpanProcessingConfig defines configuration for span name processing
@@ -28,23 +85,195 @@ type SpanProcessingConfig struct {
 	// Enabled determines if span processing is enabled
 	Enabled bool `mapstructure:"enabled"`
 	
-	// Mode: "enrich" (add attributes only) or "enforce" (override span names)
+	// Mode: "enrich" (add attributes only), "enforce" (override span names)
+	// or "audit" (report non-conforming span names without modifying them)
 	Mode ProcessingMode `mapstructure:"mode"`
 	
+	// MatchStrategy: "first" (the first matching rule wins) or "all" (every matching
+	// rule contributes, later rules overriding earlier ones)
+	MatchStrategy MatchStrategy `mapstructure:"match_strategy"`
+	
+	// AuditSampleRatio limits audit mode to a deterministic fraction of traces, chosen by
+	// hashing the trace ID, to bound overhead on large pipelines. 0 (the default) audits every span
+	AuditSampleRatio float64 `mapstructure:"audit_sample_ratio"`
+	
+	// MaxSpansPerBatch caps the overhead on very high-volume pipelines: only the first
+	// N spans of each batch are processed and counted, the rest pass through untouched.
+	// 0 (the default) processes every span
+	MaxSpansPerBatch int `mapstructure:"max_spans_per_batch"`
+	
+	// OnlySampled skips spans whose W3C trace flags do not have the sampled bit set.
+	// Spans from SDKs that do not record flags carry no bits and are skipped as well
+	OnlySampled bool `mapstructure:"only_sampled"`
+	
+	// SkipIfAttribute skips spans whose attribute equals the given value, e.g. spans an
+	// upstream tail sampler marked with sampling.keep=false. Values are compared in their
+	// string form, so a boolean false matches "false"
+	SkipIfAttribute AttributeMatch `mapstructure:"skip_if_attribute"`
+	
+	// MarkNonConformant tags spans that violate a rule with semconv.conformant=false (audit mode only)
+	MarkNonConformant bool `mapstructure:"mark_non_conformant"`
+	
+	// StampProvenance tags spans whose name or attributes the processor changed with
+	// semconv.processed=true and semconv.processor.version, for auditing downstream
+	StampProvenance bool `mapstructure:"stamp_provenance"`
+	
 	// OperationNameAttribute is the attribute name for generated operation names
 	OperationNameAttribute string `mapstructure:"operation_name_attribute"`
 	
+	// ReprocessExisting evaluates rules even when the span already has an operation name
+	// attribute, overwriting a value set by an earlier stage (default: false)
+	ReprocessExisting bool `mapstructure:"reprocess_existing"`
+	
+	// WriteOperationNameAttribute controls whether the operation name is also written as an
+	// attribute in enforce mode, where it duplicates the span name (default: true)
+	WriteOperationNameAttribute *bool `mapstructure:"write_operation_name_attribute"`
+	
+	// SkipMatchingOperationName skips the operation name attribute in enrich mode when it
+	// equals the span name, so already conformant spans do not carry the name twice
+	SkipMatchingOperationName bool `mapstructure:"skip_matching_operation_name"`
+	
 	// OperationTypeAttribute is the attribute name for operation types
 	OperationTypeAttribute string `mapstructure:"operation_type_attribute"`
 	
-	// PreserveOriginalName determines if original span name should be preserved (enforce mode only)
+	// InferOperationType derives the operation type from the span's attributes when no
+	// rule provides one, e.g. "messaging" for spans with messaging attributes
+	InferOperationType bool `mapstructure:"infer_operation_type"`
+	
+	// OperationTypeConflict decides what happens when a span already has an operation
+	// type: "keep" (the default), "overwrite" or "keep-if-nonempty", which only
+	// replaces empty values
+	OperationTypeConflict OperationTypeConflictPolicy `mapstructure:"operation_type_conflict"`
+	
+	// OperationTypePrecedence decides which matching rule's operation type applies when
+	// several rules match under the "all" strategy: "last" (the default) or "first"
+	OperationTypePrecedence OperationTypePrecedence `mapstructure:"operation_type_precedence"`
+	
+	// RoutingClassMap maps operation types to low-cardinality classes written to
+	// RoutingClassAttribute, e.g. for a routing connector (not applied in audit mode)
+	RoutingClassMap map[string]string `mapstructure:"routing_class_map"`
+	
+	// RoutingClassAttribute is the attribute name for routing classes (default: routing.class)
+	RoutingClassAttribute string `mapstructure:"routing_class_attribute"`
+	
+	// EnrichSetNameIfGeneric sets the span name in enrich mode when the current name
+	// matches GenericNamePattern, e.g. a bare HTTP method; other names are left alone
+	EnrichSetNameIfGeneric bool `mapstructure:"enrich_set_name_if_generic"`
+	
+	// GenericNamePattern is the regular expression identifying generic span names for
+	// EnrichSetNameIfGeneric (default: empty names, HTTP methods and "HTTP <method>")
+	GenericNamePattern string `mapstructure:"generic_name_pattern"`
+	
+	// OnlyIfNormalized skips rewriting spans whose name already equals the generated
+	// operation name, so they are not counted as enforced (enforce mode only)
+	OnlyIfNormalized bool `mapstructure:"only_if_normalized"`
+	
+	// PreserveOriginalName determines if original span name should be preserved when it is
+	// replaced (enforce mode, or generic names with EnrichSetNameIfGeneric)
 	PreserveOriginalName bool `mapstructure:"preserve_original_name"`
 	
 	// OriginalNameAttribute is the attribute name for storing original span name
 	OriginalNameAttribute string `mapstructure:"original_name_attribute"`
 	
+	// UseAttributeAsName promotes the value of this span attribute to the span name
+	// before rules are evaluated, so rules can still override it (not applied in audit mode)
+	UseAttributeAsName string `mapstructure:"use_attribute_as_name"`
+	
+	// NormalizeHTTPMethod uppercases http.request.method and maps unknown methods to _OTHER
+	// before rules are evaluated, keeping the raw value in http.request.method_original
+	// (not applied in audit mode)
+	NormalizeHTTPMethod bool `mapstructure:"normalize_http_method"`
+	
+	// NetToServerClient converts deprecated net.* attributes to server.*, client.* and
+	// network.* before rules are evaluated, using the span kind to decide whether
+	// net.peer.* describes the server or the client (not applied in audit mode)
+	NetToServerClient bool `mapstructure:"net_to_server_client"`
+	
+	// ProcessLinkAttributes also applies the attribute migrations to the attributes of
+	// span links, so migrations cover the whole span
+	ProcessLinkAttributes bool `mapstructure:"process_link_attributes"`
+	
+	// AttributeSynonyms list attributes that duplicate a canonical attribute, e.g. during
+	// a gradual migration. A synonym with the same value as its canonical attribute is
+	// removed before rules are evaluated (not applied in audit mode)
+	AttributeSynonyms []AttributeSynonyms `mapstructure:"attribute_synonyms"`
+	
+	// MappingPhase decides whether the attribute migrations (net_to_server_client and
+	// attribute_synonyms) run "before" rules are evaluated, the default, so rules see the
+	// current attribute names, or "after", so rules see the attributes as received
+	MappingPhase MappingPhase `mapstructure:"mapping_phase"`
+	
+	// DestinationNormalizers rewrite messaging.destination.name (and the deprecated
+	// messaging.destination) before rules are evaluated, e.g. to strip partition
+	// suffixes from topic names (not applied in audit mode)
+	DestinationNormalizers []KeyRewrite `mapstructure:"destination_normalizers"`
+	
+	// PathNormalization tunes how NormalizePath, NormalizePathExcept, BuildHTTPTarget
+	// and emit_normalized_path_attribute replace IDs in paths
+	PathNormalization PathNormalizationConfig `mapstructure:"path_normalization"`
+	
+	// EmitNormalizedPathAttribute, when set, writes the normalized url.path (or the
+	// deprecated http.target) to this attribute, e.g. url.path.template, as an
+	// aggregation key that leaves the span name alone (not applied in audit mode)
+	EmitNormalizedPathAttribute string `mapstructure:"emit_normalized_path_attribute"`
+	
+	// ScopeRewrites rewrite the instrumentation scope name once per scope before its
+	// spans are processed, e.g. to consolidate legacy library names (not applied in audit mode)
+	ScopeRewrites []KeyRewrite `mapstructure:"scope_rewrites"`
+	
+	// ExceptionEvents normalizes the attributes of exception span events before rules
+	// are evaluated (not applied in audit mode)
+	ExceptionEvents ExceptionEventsConfig `mapstructure:"exception_events"`
+	
+	// MinPriority and MaxPriority, when set, limit compilation to rules whose priority
+	// lies within the inclusive window, so rules can be switched off without editing them
+	MinPriority *int `mapstructure:"min_priority"`
+	MaxPriority *int `mapstructure:"max_priority"`
+	
+	// IndexParents indexes each batch by span ID before processing so rules can read
+	// attributes of a span's parent with ParentAttribute
+	IndexParents bool `mapstructure:"index_parents"`
+	
+	// AttachErrorEvents adds a semconv.error span event when a rule fails to evaluate,
+	// capped per batch so a broken rule cannot flood the data
+	AttachErrorEvents bool `mapstructure:"attach_error_events"`
+	
+	// DisabledFunctions lists OTTL functions that rules may not use.
+	// They are removed from the parser so they cannot be called.
+	DisabledFunctions []string `mapstructure:"disabled_functions"`
+	
+	// DropConditions are OTTL conditions; spans matching any of them are removed from
+	// the batch before rules are evaluated, e.g. health checks. They apply even when span
+	// processing is disabled (not applied in audit mode)
+	DropConditions []string `mapstructure:"drop_conditions"`
+	
+	// SpanKindRules correct the kind of spans that instrumentation left internal, e.g.
+	// HTTP server spans. The first rule whose condition matches sets the kind, before
+	// rules are evaluated (not applied in audit mode)
+	SpanKindRules []SpanKindRule `mapstructure:"span_kind_rules"`
+	
+	// OverrideSpanKind lets span_kind_rules change spans whose kind is already set to
+	// something other than internal or unspecified (default: false)
+	OverrideSpanKind bool `mapstructure:"override_span_kind"`
+	
+	// NameMappings maps exact span names to canonical operation names.
+	// It is checked before the OTTL rules; a hit skips rule evaluation.
+	NameMappings map[string]string `mapstructure:"name_mappings"`
+	
 	// Rules defines OTTL rules for span name generation
 	Rules []OTTLRule `mapstructure:"rules"`
+	
+	// RulesFiles lists YAML files with a top-level rules list in the same format as Rules.
+	// Their rules are merged with Rules and ordered by priority across all sources; rules
+	// of equal priority keep their order, with Rules first and the files in the order listed
+	RulesFiles []string `mapstructure:"rules_files"`
+	
+	// NamespaceRuleIDs prefixes the IDs of rules loaded from rules_files with the file
+	// name without its extension (http.yaml: get → http.get), so files may reuse IDs
+	NamespaceRuleIDs bool `mapstructure:"namespace_rule_ids"`
+	
+	// rulesFilesLoaded prevents a second Validate from merging the files again
+	rulesFilesLoaded bool
 }
 
 // ProcessingMode defines how span names are processed
@@ -56,13 +285,271 @@ const (
 	
 	// ModeEnforce replaces span name with generated operation name
 	ModeEnforce ProcessingMode = "enforce"
+	
+	// ModeAudit reports spans whose name differs from the generated operation name without modifying them
+	ModeAudit ProcessingMode = "audit"
+)
+
+// MatchStrategy defines how many matching rules are applied to a span
+type MatchStrategy string
+
+const (
+	// MatchFirst applies only the first matching rule in priority order
+	MatchFirst MatchStrategy = "first"
+	
+	// MatchAll applies every matching rule in priority order; later rules override
+	// the operation name and type, and all extra attributes are written
+	MatchAll MatchStrategy = "all"
+)
+
+// OperationTypeConflictPolicy defines how a computed operation type treats an existing one
+type OperationTypeConflictPolicy string
+
+const (
+	// OperationTypeKeep preserves an existing operation type
+	OperationTypeKeep OperationTypeConflictPolicy = "keep"
+	
+	// OperationTypeOverwrite replaces an existing operation type
+	OperationTypeOverwrite OperationTypeConflictPolicy = "overwrite"
+	
+	// OperationTypeKeepIfNonEmpty preserves an existing operation type unless it is empty
+	OperationTypeKeepIfNonEmpty OperationTypeConflictPolicy = "keep-if-nonempty"
+)
+
+// OperationTypePrecedence defines which of several matching rules sets the operation type
+type OperationTypePrecedence string
+
+const (
+	// OperationTypeLastWins applies the type of the last matching rule in priority order
+	OperationTypeLastWins OperationTypePrecedence = "last"
+	
+	// OperationTypeFirstWins applies the type of the first matching rule that computes one
+	OperationTypeFirstWins OperationTypePrecedence = "first"
+)
+
+// MappingPhase defines when the attribute migrations run relative to the rules
+type MappingPhase string
+
+const (
+	// MappingPhaseBefore migrates attributes before rules are evaluated
+	MappingPhaseBefore MappingPhase = "before"
+	
+	// MappingPhaseAfter migrates attributes after rules are evaluated
+	MappingPhaseAfter MappingPhase = "after"
 )
 
+// AttributeMatch names an attribute and the value it must have
+type AttributeMatch struct {
+	// Key is the attribute name
+	Key string `mapstructure:"key"`
+	
+	// Value is compared with the string form of the attribute value
+	Value string `mapstructure:"value"`
+}
+
+// AttributeSynonyms names a canonical attribute and the keys that duplicate it
+type AttributeSynonyms struct {
+	// Canonical is the attribute that is kept
+	Canonical string `mapstructure:"canonical"`
+	
+	// Synonyms are removed when they hold the same value as Canonical
+	Synonyms []string `mapstructure:"synonyms"`
+}
+
+// KeyRewrite replaces every match of a regular expression in a value
+type KeyRewrite struct {
+	// Pattern is a regular expression in RE2 syntax
+	Pattern string `mapstructure:"pattern"`
+	
+	// Replacement replaces each match; $1-style group references are expanded
+	Replacement string `mapstructure:"replacement"`
+}
+
+// SchemaMigrationConfig defines the schema file and version telemetry is migrated to
+type SchemaMigrationConfig struct {
+	// File is the path of an OpenTelemetry schema file. Its rename_attributes changes in
+	// the all, resources, spans, metrics and logs sections and its rename_metrics changes
+	// are applied
+	File string `mapstructure:"file"`
+	
+	// TargetVersion is the schema version telemetry is migrated to, upgrading or
+	// downgrading as needed (default: the newest version in the file)
+	TargetVersion string `mapstructure:"target_version"`
+	
+	// TargetVersionAttribute names a resource attribute holding the version that
+	// resource's telemetry is migrated to, so one processor serves tenants on different
+	// versions. Resources without it use TargetVersion; a version the file does not list
+	// is logged and the resource left unmigrated.
+	TargetVersionAttribute string `mapstructure:"target_version_attribute"`
+	
+	// VersionAttribute names a resource attribute, e.g. semconv.version, that migrated
+	// resources get set to the version they were migrated to. Resources whose source
+	// version has no migration plan do not get it (default: not written)
+	VersionAttribute string `mapstructure:"version_attribute"`
+	
+	// schema is the file parsed by Validate
+	schema *schemaFile
+}
+
+// enabled reports whether a schema file is configured
+func (sm *SchemaMigrationConfig) enabled() bool {
+	return sm.File != ""
+}
+
+// Validate loads the schema file and checks the target version is listed in it
+func (sm *SchemaMigrationConfig) Validate() error {
+	if sm.schema == nil {
+		schema, err := loadSchemaFile(sm.File)
+		if err != nil {
+			return err
+		}
+		sm.schema = schema
+	}
+	if sm.TargetVersion == "" {
+		sm.TargetVersion = sm.schema.newestVersion()
+	}
+	return sm.schema.checkTarget(sm.TargetVersion)
+}
+
+// SpanKindRule sets the span kind of spans matching an OTTL condition
+type SpanKindRule struct {
+	// Condition is an OTTL condition in the span context
+	Condition string `mapstructure:"condition"`
+	
+	// Kind is the span kind to set: "server", "client", "producer", "consumer" or "internal"
+	Kind string `mapstructure:"kind"`
+}
+
+// ServiceClassificationConfig defines the labels derived from service.name
+type ServiceClassificationConfig struct {
+	// Attribute is the resource attribute the label is written to (default: service.tier).
+	// Resources that already have it are left alone
+	Attribute string `mapstructure:"attribute"`
+	
+	// Default is the label of services no rule matches (default: unknown)
+	Default string `mapstructure:"default"`
+	
+	// Rules are tried in order against service.name; the first match wins
+	Rules []ServiceClassificationRule `mapstructure:"rules"`
+}
+
+// ServiceClassificationRule assigns a label to the services whose name matches a pattern
+type ServiceClassificationRule struct {
+	// Pattern is a regular expression matched against service.name
+	Pattern string `mapstructure:"pattern"`
+	
+	// Label is written when the pattern matches, e.g. "critical"
+	Label string `mapstructure:"label"`
+}
+
+// enabled reports whether any classification rule is configured
+func (sc *ServiceClassificationConfig) enabled() bool {
+	return len(sc.Rules) > 0
+}
+
+// Validate checks the patterns compile and every rule has a label
+func (sc *ServiceClassificationConfig) Validate() error {
+	for i, rule := range sc.Rules {
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("rule at index %d has invalid pattern %q: %w", i, rule.Pattern, err)
+		}
+		if rule.Label == "" {
+			return fmt.Errorf("rule at index %d has empty label", i)
+		}
+	}
+	
+	if sc.Attribute == "" {
+		sc.Attribute = "service.tier"
+	}
+	if sc.Default == "" {
+		sc.Default = "unknown"
+	}
+	return nil
+}
+
+// LogProcessingConfig defines how operation names are derived from log records
+type LogProcessingConfig struct {
+	// Enabled determines if log processing is enabled
+	Enabled bool `mapstructure:"enabled"`
+	
+	// OperationNameAttribute is the log attribute the operation name is written to
+	// (default: operation.name). Records that already have it are left alone
+	OperationNameAttribute string `mapstructure:"operation_name_attribute"`
+	
+	// PathNormalization tunes how IDs in the extracted path are replaced
+	PathNormalization PathNormalizationConfig `mapstructure:"path_normalization"`
+	
+	// Rules are tried in order against the log body; the first match wins
+	Rules []LogRule `mapstructure:"rules"`
+}
+
+// LogRule extracts a request method and path from a log body
+type LogRule struct {
+	// ID is a unique identifier for the rule
+	ID string `mapstructure:"id"`
+	
+	// Pattern is a regular expression with the named groups "method" and "path",
+	// e.g. for the request line of an access log
+	Pattern string `mapstructure:"pattern"`
+}
+
+// PathNormalizationConfig defines which path segments are replaced by {id}
+type PathNormalizationConfig struct {
+	// MinIDDigits is the minimum number of digits a numeric segment needs to be
+	// replaced, so e.g. 2 keeps version segments like /v2/1 (default: 1)
+	MinIDDigits int `mapstructure:"min_id_digits"`
+	
+	// TypedPlaceholders replaces IDs with {uuid}, {num} or {hex} by their type instead
+	// of {id} for all of them (default: false)
+	TypedPlaceholders bool `mapstructure:"typed_placeholders"`
+	
+	// MaxDepth truncates normalized paths to this many segments, appending /...
+	// (default: 0, no truncation)
+	MaxDepth int `mapstructure:"max_depth"`
+	
+	// CleanPath collapses duplicate slashes and resolves . and .. segments before IDs
+	// are replaced, never climbing above the root (default: false)
+	CleanPath bool `mapstructure:"clean_path"`
+}
+
+// validate checks that the path normalization settings are not negative
+func (p PathNormalizationConfig) validate() error {
+	if p.MinIDDigits < 0 {
+		return fmt.Errorf("path_normalization.min_id_digits must not be negative, got %d", p.MinIDDigits)
+	}
+	if p.MaxDepth < 0 {
+		return fmt.Errorf("path_normalization.max_depth must not be negative, got %d", p.MaxDepth)
+	}
+	return nil
+}
+
+// ExceptionEventsConfig defines how exception span events are normalized
+type ExceptionEventsConfig struct {
+	// MaxStacktraceFrames keeps the exception message and this many frames (lines) of
+	// exception.stacktrace. 0 (the default) keeps every frame
+	MaxStacktraceFrames int `mapstructure:"max_stacktrace_frames"`
+	
+	// MaxStacktraceBytes truncates exception.stacktrace to this many bytes. 0 (the default)
+	// disables the limit
+	MaxStacktraceBytes int `mapstructure:"max_stacktrace_bytes"`
+	
+	// ShortenType strips the package or namespace from exception.type, keeping the class name
+	ShortenType bool `mapstructure:"shorten_type"`
+}
+
+// enabled reports whether any exception event normalization is configured
+func (c ExceptionEventsConfig) enabled() bool {
+	return c.MaxStacktraceFrames > 0 || c.MaxStacktraceBytes > 0 || c.ShortenType
+}
+
 // OTTLRule defines a single OTTL-based rule for span name generation
 type OTTLRule struct {
 	// ID is a unique identifier for the rule
 	ID string `mapstructure:"id"`
 	
+	// Enabled switches the rule off when false, so it is neither compiled nor evaluated (default: true)
+	Enabled *bool `mapstructure:"enabled"`
+	
 	// Priority determines rule evaluation order (lower number = higher priority)
 	Priority int `mapstructure:"priority"`
 	
@@ -77,16 +564,141 @@ type OTTLRule struct {
 	// OperationName is an OTTL expression that generates the operation name
 	OperationName string `mapstructure:"operation_name"`
 	
+	// OperationNameCandidates is an alternative to OperationName: OTTL expressions tried
+	// in order until one yields a non-empty name without error
+	OperationNameCandidates []string `mapstructure:"operation_name_candidates"`
+	
+	// Template is a simpler alternative to OperationName: text with {attribute}
+	// placeholders, e.g. "{http.request.method} {http.route}", resolved against the
+	// span attributes. Missing attributes render as empty and the result is trimmed
+	Template string `mapstructure:"template"`
+	
 	// OperationType is an optional OTTL expression that generates the operation type
 	OperationType string `mapstructure:"operation_type"`
+	
+	// ExtraAttributes maps attribute names to OTTL expressions evaluated when the rule matches.
+	// The results are written alongside the operation name (not in audit mode)
+	ExtraAttributes map[string]string `mapstructure:"extra_attributes"`
+}
+
+// validSignals lists the signal names accepted in Config.Signals
+var validSignals = map[string]bool{
+	"traces":   true,
+	"metrics":  true,
+	"logs":     true,
+	"profiles": true,
+}
+
+// signalEnabled reports whether the processor handles the given signal
+func (cfg *Config) signalEnabled(signal string) bool {
+	if len(cfg.Signals) == 0 {
+		return true
+	}
+	for _, s := range cfg.Signals {
+		if s == signal {
+			return true
+		}
+	}
+	return false
 }
 
 // Validate checks if the configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.BenchmarkInterval < 0 {
+		return fmt.Errorf("benchmark_interval must not be negative, got %s", cfg.BenchmarkInterval)
+	}
+	if cfg.RuleMatchWindow < 0 {
+		return fmt.Errorf("rule_match_window must not be negative, got %s", cfg.RuleMatchWindow)
+	}
+	if cfg.RuleMatchWindow > 0 && !cfg.Benchmark && (!cfg.SpanProcessing.Enabled || cfg.SpanProcessing.Mode != ModeAudit) {
+		return errors.New("rule_match_window requires benchmark or span_processing audit mode")
+	}
+	if cfg.BenchmarkExportPath != "" && !cfg.Benchmark {
+		return errors.New("benchmark_export_path requires benchmark to be enabled")
+	}
+	if cfg.MetricsSampleRatio < 0 || cfg.MetricsSampleRatio > 1 {
+		return fmt.Errorf("metrics_sample_ratio must be between 0 and 1, got %v", cfg.MetricsSampleRatio)
+	}
+	for _, signal := range cfg.Signals {
+		if !validSignals[signal] {
+			return fmt.Errorf("invalid signal %q in signals, must be one of 'traces', 'metrics', 'logs' or 'profiles'", signal)
+		}
+	}
+	if cfg.SemconvVersion != "" {
+		if _, supported := semconvRegistries[cfg.SemconvVersion]; !supported {
+			return fmt.Errorf("unsupported semconv_version %q, must be one of %s", cfg.SemconvVersion, strings.Join(supportedSemconvVersions(), ", "))
+		}
+	}
+	switch {
+	case cfg.MaxRegexInputBytes == 0:
+		cfg.MaxRegexInputBytes = defaultMaxRegexInputBytes
+	case cfg.MaxRegexInputBytes < unlimitedRegexInputBytes:
+		return fmt.Errorf("max_regex_input_bytes must be positive, or -1 to disable the limit, got %d", cfg.MaxRegexInputBytes)
+	}
+	for _, key := range cfg.KnownAttributes {
+		if key == "" {
+			return errors.New("known_attributes contains an empty attribute name")
+		}
+	}
+	for _, key := range cfg.ResourceAttributeAllowlist {
+		if key == "" {
+			return errors.New("resource_attribute_allowlist contains an empty attribute name")
+		}
+	}
 	if cfg.SpanProcessing.Enabled {
 		if err := cfg.SpanProcessing.Validate(); err != nil {
 			return fmt.Errorf("span_processing validation failed: %w", err)
 		}
+	} else if err := cfg.SpanProcessing.validateDropConditions(); err != nil {
+		// Drop conditions apply even when the rules are disabled
+		return fmt.Errorf("span_processing validation failed: %w", err)
+	}
+	if cfg.LogProcessing.Enabled {
+		if err := cfg.LogProcessing.Validate(); err != nil {
+			return fmt.Errorf("log_processing validation failed: %w", err)
+		}
+	}
+	if cfg.SchemaMigration.enabled() {
+		if err := cfg.SchemaMigration.Validate(); err != nil {
+			return fmt.Errorf("schema_migration validation failed: %w", err)
+		}
+	}
+	if err := cfg.ServiceClassification.Validate(); err != nil {
+		return fmt.Errorf("service_classification validation failed: %w", err)
+	}
+	return nil
+}
+
+// Validate checks if the log processing configuration is valid
+func (lp *LogProcessingConfig) Validate() error {
+	if len(lp.Rules) == 0 {
+		return errors.New("at least one rule must be defined")
+	}
+	if err := lp.PathNormalization.validate(); err != nil {
+		return err
+	}
+	
+	seenIDs := make(map[string]bool)
+	for i, rule := range lp.Rules {
+		if rule.ID == "" {
+			return fmt.Errorf("rule at index %d has empty ID", i)
+		}
+		if seenIDs[rule.ID] {
+			return fmt.Errorf("duplicate rule ID: %s", rule.ID)
+		}
+		seenIDs[rule.ID] = true
+		
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("rule %s has invalid pattern %q: %w", rule.ID, rule.Pattern, err)
+		}
+		if pattern.SubexpIndex("method") < 0 || pattern.SubexpIndex("path") < 0 {
+			return fmt.Errorf("rule %s pattern must have the named groups method and path", rule.ID)
+		}
+	}
+	
+	if lp.OperationNameAttribute == "" {
+		lp.OperationNameAttribute = "operation.name"
 	}
 	return nil
 }
@@ -95,13 +707,110 @@ func (cfg *Config) Validate() error {
 func (sp *SpanProcessingConfig) Validate() error {
 	// Validate mode
 	switch sp.Mode {
-	case ModeEnrich, ModeEnforce:
+	case ModeEnrich, ModeEnforce, ModeAudit:
 		// Valid modes
 	case "":
 		// Default to enrich if not specified
 		sp.Mode = ModeEnrich
 	default:
-		return fmt.Errorf("invalid mode %q, must be 'enrich' or 'enforce'", sp.Mode)
+		return fmt.Errorf("invalid mode %q, must be 'enrich', 'enforce' or 'audit'", sp.Mode)
+	}
+	
+	// Validate match strategy
+	switch sp.MatchStrategy {
+	case MatchFirst, MatchAll:
+		// Valid strategies
+	case "":
+		// Default to first match wins
+		sp.MatchStrategy = MatchFirst
+	default:
+		return fmt.Errorf("invalid match_strategy %q, must be 'first' or 'all'", sp.MatchStrategy)
+	}
+	
+	// Validate operation type conflict policy
+	switch sp.OperationTypeConflict {
+	case OperationTypeKeep, OperationTypeOverwrite, OperationTypeKeepIfNonEmpty:
+		// Valid policies
+	case "":
+		// Default to keeping existing values
+		sp.OperationTypeConflict = OperationTypeKeep
+	default:
+		return fmt.Errorf("invalid operation_type_conflict %q, must be 'keep', 'overwrite' or 'keep-if-nonempty'", sp.OperationTypeConflict)
+	}
+	
+	// Validate operation type precedence
+	switch sp.OperationTypePrecedence {
+	case OperationTypeLastWins, OperationTypeFirstWins:
+		// Valid precedences
+	case "":
+		// Default to the last matching rule, consistent with the operation name
+		sp.OperationTypePrecedence = OperationTypeLastWins
+	default:
+		return fmt.Errorf("invalid operation_type_precedence %q, must be 'last' or 'first'", sp.OperationTypePrecedence)
+	}
+	
+	// Validate mapping phase
+	switch sp.MappingPhase {
+	case MappingPhaseBefore, MappingPhaseAfter:
+		// Valid phases
+	case "":
+		// Default to migrating before rules
+		sp.MappingPhase = MappingPhaseBefore
+	default:
+		return fmt.Errorf("invalid mapping_phase %q, must be 'before' or 'after'", sp.MappingPhase)
+	}
+	
+	if sp.ExceptionEvents.MaxStacktraceFrames < 0 {
+		return fmt.Errorf("exception_events.max_stacktrace_frames must not be negative, got %d", sp.ExceptionEvents.MaxStacktraceFrames)
+	}
+	if sp.ExceptionEvents.MaxStacktraceBytes < 0 {
+		return fmt.Errorf("exception_events.max_stacktrace_bytes must not be negative, got %d", sp.ExceptionEvents.MaxStacktraceBytes)
+	}
+	
+	if sp.SkipIfAttribute.Key == "" && sp.SkipIfAttribute.Value != "" {
+		return errors.New("skip_if_attribute has a value but no key")
+	}
+	
+	if sp.AuditSampleRatio < 0 || sp.AuditSampleRatio > 1 {
+		return fmt.Errorf("audit_sample_ratio must be between 0 and 1, got %v", sp.AuditSampleRatio)
+	}
+	if sp.MaxSpansPerBatch < 0 {
+		return fmt.Errorf("max_spans_per_batch must not be negative, got %d", sp.MaxSpansPerBatch)
+	}
+	
+	if err := validateRewrites("destination_normalizers", sp.DestinationNormalizers); err != nil {
+		return err
+	}
+	if err := validateRewrites("scope_rewrites", sp.ScopeRewrites); err != nil {
+		return err
+	}
+	
+	if err := sp.PathNormalization.validate(); err != nil {
+		return err
+	}
+	
+	for _, key := range pathAttributes {
+		if sp.EmitNormalizedPathAttribute == key {
+			return fmt.Errorf("emit_normalized_path_attribute must not overwrite the source attribute %q", key)
+		}
+	}
+	
+	for i, set := range sp.AttributeSynonyms {
+		if set.Canonical == "" {
+			return fmt.Errorf("attribute_synonyms[%d] has an empty canonical attribute", i)
+		}
+		if len(set.Synonyms) == 0 {
+			return fmt.Errorf("attribute_synonyms[%d] (%s) has no synonyms", i, set.Canonical)
+		}
+		for _, synonym := range set.Synonyms {
+			if synonym == "" || synonym == set.Canonical {
+				return fmt.Errorf("attribute_synonyms[%d] (%s) has an invalid synonym %q", i, set.Canonical, synonym)
+			}
+		}
+	}
+	
+	if sp.MinPriority != nil && sp.MaxPriority != nil && *sp.MinPriority > *sp.MaxPriority {
+		return fmt.Errorf("min_priority (%d) must not be greater than max_priority (%d)", *sp.MinPriority, *sp.MaxPriority)
 	}
 	
 	// Set default attribute names if not specified
@@ -114,9 +823,71 @@ func (sp *SpanProcessingConfig) Validate() error {
 	if sp.OriginalNameAttribute == "" {
 		sp.OriginalNameAttribute = "name.original"
 	}
+	if sp.RoutingClassAttribute == "" {
+		sp.RoutingClassAttribute = "routing.class"
+	}
+	if sp.EnrichSetNameIfGeneric && sp.GenericNamePattern == "" {
+		sp.GenericNamePattern = defaultGenericNamePattern
+	}
+	if _, err := regexp.Compile(sp.GenericNamePattern); err != nil {
+		return fmt.Errorf("invalid generic_name_pattern %q: %w", sp.GenericNamePattern, err)
+	}
+	if sp.WriteOperationNameAttribute == nil {
+		writeOperationNameAttribute := true
+		sp.WriteOperationNameAttribute = &writeOperationNameAttribute
+	}
+	
+	// Validate disabled functions exist so typos don't silently leave a function enabled
+	availableFunctions := ottlFunctions[ottlspan.TransformContext](defaultPathNormalizer, nil, nil)
+	for _, name := range sp.DisabledFunctions {
+		if _, exists := availableFunctions[name]; !exists {
+			return fmt.Errorf("disabled_functions contains unknown function %q", name)
+		}
+	}
+	
+	// Validate name mappings
+	for from, to := range sp.NameMappings {
+		if from == "" {
+			return errors.New("name_mappings contains an empty span name")
+		}
+		if to == "" {
+			return fmt.Errorf("name_mappings entry %q has an empty operation name", from)
+		}
+	}
+	
+	// Merge rules from files before validation so they are checked and sorted with the rest
+	if len(sp.RulesFiles) > 0 && !sp.rulesFilesLoaded {
+		fileRules, err := loadRulesFiles(sp.RulesFiles, sp.NamespaceRuleIDs)
+		if err != nil {
+			return err
+		}
+		sp.Rules = append(sp.Rules, fileRules...)
+		sp.rulesFilesLoaded = true
+	}
+	
+	for operationType, class := range sp.RoutingClassMap {
+		if operationType == "" {
+			return errors.New("routing_class_map contains an empty operation type")
+		}
+		if class == "" {
+			return fmt.Errorf("routing_class_map entry %q has an empty class", operationType)
+		}
+	}
+	
+	if err := sp.validateDropConditions(); err != nil {
+		return err
+	}
+	for i, rule := range sp.SpanKindRules {
+		if rule.Condition == "" {
+			return fmt.Errorf("span_kind_rules[%d] has empty condition", i)
+		}
+		if _, valid := spanKindsByName[rule.Kind]; !valid {
+			return fmt.Errorf("span_kind_rules[%d] has invalid kind %q, must be one of server, client, producer, consumer, internal", i, rule.Kind)
+		}
+	}
 	
 	// Validate rules
-	if len(sp.Rules) == 0 {
+	if len(sp.Rules) == 0 && len(sp.NameMappings) == 0 {
 		return errors.New("at least one rule must be defined")
 	}
 	
@@ -133,9 +904,61 @@ func (sp *SpanProcessingConfig) Validate() error {
 		if rule.Condition == "" {
 			return fmt.Errorf("rule %s has empty condition", rule.ID)
 		}
-		if rule.OperationName == "" {
+		if rule.OperationName == "" && len(rule.OperationNameCandidates) == 0 && rule.Template == "" {
 			return fmt.Errorf("rule %s has empty operation_name", rule.ID)
 		}
+		if rule.OperationName != "" && len(rule.OperationNameCandidates) > 0 {
+			return fmt.Errorf("rule %s sets both operation_name and operation_name_candidates", rule.ID)
+		}
+		if rule.Template != "" {
+			if rule.OperationName != "" || len(rule.OperationNameCandidates) > 0 {
+				return fmt.Errorf("rule %s sets both template and operation_name", rule.ID)
+			}
+			if _, err := parseOperationTemplate(rule.Template); err != nil {
+				return fmt.Errorf("rule %s has invalid template %q: %w", rule.ID, rule.Template, err)
+			}
+		}
+		for _, expr := range rule.OperationNameCandidates {
+			if expr == "" {
+				return fmt.Errorf("rule %s has an empty expression in operation_name_candidates", rule.ID)
+			}
+		}
+		for name, expr := range rule.ExtraAttributes {
+			if name == "" {
+				return fmt.Errorf("rule %s has an extra attribute with an empty name", rule.ID)
+			}
+			if expr == "" {
+				return fmt.Errorf("rule %s has empty expression for extra attribute %q", rule.ID, name)
+			}
+		}
+		
+		// Reject rules that call a disabled function
+		exprs := rule.expressions()
+		for _, name := range sp.DisabledFunctions {
+			for _, expr := range exprs {
+				if referencesFunction(expr, name) {
+					return fmt.Errorf("rule %s uses disabled function %s", rule.ID, name)
+				}
+			}
+		}
+		
+		if !sp.IndexParents {
+			for _, expr := range exprs {
+				if referencesFunction(expr, "ParentAttribute") {
+					return fmt.Errorf("rule %s uses ParentAttribute, which requires index_parents", rule.ID)
+				}
+			}
+		}
+		
+		// Rules are evaluated in the span context; paths of other contexts only fail
+		// once the processor is built, so reject them together with the rest of the config
+		for _, name := range unsupportedContexts {
+			for _, expr := range exprs {
+				if referencesContext(expr, name) {
+					return fmt.Errorf("rule %s references the %s context, which is not available to span rules (use span, resource or instrumentation_scope paths)", rule.ID, name)
+				}
+			}
+		}
 		
 		// Validate span_kind values if specified
 		validSpanKinds := map[string]bool{
@@ -152,12 +975,135 @@ func (sp *SpanProcessingConfig) Validate() error {
 		}
 	}
 	
-	// Sort rules by priority for consistent evaluation order
-	sort.Slice(sp.Rules, func(i, j int) bool {
+	// Enforce mode without anything that can rename a span is almost certainly a mistake
+	if sp.Mode == ModeEnforce && !sp.canChangeNames() {
+		return errors.New("enforce mode requires at least one enabled rule inside the priority window or a name mapping, otherwise no span name can change")
+	}
+	
+	// Sort rules by priority for consistent evaluation order. Rules of equal priority keep
+	// their declaration order: inline rules first, then each of rules_files in turn
+	sort.SliceStable(sp.Rules, func(i, j int) bool {
 		return sp.Rules[i].Priority < sp.Rules[j].Priority
 	})
 	
 	return nil
 }
 
+// validateDropConditions checks the drop conditions, which are validated on their own
+// when span processing is disabled
+func (sp *SpanProcessingConfig) validateDropConditions() error {
+	for i, expr := range sp.DropConditions {
+		if expr == "" {
+			return fmt.Errorf("drop_conditions[%d] is empty", i)
+		}
+	}
+	return nil
+}
+
+// writesOperationNameAttribute reports whether the operation name attribute should be written,
+// treating an unset option as enabled
+func (sp *SpanProcessingConfig) writesOperationNameAttribute() bool {
+	return sp.WriteOperationNameAttribute == nil || *sp.WriteOperationNameAttribute
+}
+
+// canChangeNames reports whether any name mapping or compiled rule can produce a span name.
+// Every rule sets a name, but disabled rules and rules outside the priority window are
+// never compiled.
+func (sp *SpanProcessingConfig) canChangeNames() bool {
+	if len(sp.NameMappings) > 0 {
+		return true
+	}
+	for _, rule := range sp.Rules {
+		if rule.isEnabled() && sp.inPriorityWindow(rule.Priority) {
+			return true
+		}
+	}
+	return false
+}
+
+// inPriorityWindow reports whether a rule priority lies within min_priority and max_priority
+func (sp *SpanProcessingConfig) inPriorityWindow(priority int) bool {
+	if sp.MinPriority != nil && priority < *sp.MinPriority {
+		return false
+	}
+	if sp.MaxPriority != nil && priority > *sp.MaxPriority {
+		return false
+	}
+	return true
+}
+
+// validateRewrites checks that every rewrite has a pattern that compiles
+func validateRewrites(field string, rewrites []KeyRewrite) error {
+	for i, rewrite := range rewrites {
+		if rewrite.Pattern == "" {
+			return fmt.Errorf("%s[%d] has an empty pattern", field, i)
+		}
+		if _, err := regexp.Compile(rewrite.Pattern); err != nil {
+			return fmt.Errorf("%s[%d] has invalid pattern %q: %w", field, i, rewrite.Pattern, err)
+		}
+	}
+	return nil
+}
+
+// isEnabled reports whether the rule is enabled, treating an unset flag as enabled
+func (r *OTTLRule) isEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// expressions returns every OTTL expression of the rule
+func (r *OTTLRule) expressions() []string {
+	exprs := []string{r.Condition, r.OperationName, r.OperationType}
+	exprs = append(exprs, r.OperationNameCandidates...)
+	for _, expr := range r.ExtraAttributes {
+		exprs = append(exprs, expr)
+	}
+	return exprs
+}
+
+// unsupportedContexts are OTTL path contexts the span parser cannot resolve
+var unsupportedContexts = []string{"log", "metric", "datapoint", "spanevent", "profile"}
+
+// defaultGenericNamePattern matches span names that carry no operation, such as the
+// bare HTTP method some instrumentations fall back to
+const defaultGenericNamePattern = `^(?:|(?:HTTP )?(?:GET|HEAD|POST|PUT|DELETE|CONNECT|OPTIONS|TRACE|PATCH|_OTHER))$`
+
+// stringLiteralPattern matches OTTL string literals, which may contain path-like text
+var stringLiteralPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+// referencesContext reports whether an OTTL expression uses a path of the named context
+func referencesContext(expr, name string) bool {
+	re := regexp.MustCompile(`(^|[^A-Za-z0-9_.])` + regexp.QuoteMeta(name) + `\.`)
+	return re.MatchString(stringLiteralPattern.ReplaceAllString(expr, `""`))
+}
+
+// referencesFunction reports whether an OTTL expression calls the named function
+func referencesFunction(expr, name string) bool {
+	re := regexp.MustCompile(`(^|[^A-Za-z0-9_.])` + regexp.QuoteMeta(name) + `\s*\(`)
+	return re.MatchString(stringLiteralPattern.ReplaceAllString(expr, `""`))
+}
+
+// processorAttributes returns the span attributes this processor writes, under their
+// configured names, so the unknown attributes report does not flag its own output
+func (cfg *Config) processorAttributes() []string {
+	sp := &cfg.SpanProcessing
+	candidates := []string{
+		sp.OperationNameAttribute,
+		sp.OperationTypeAttribute,
+		sp.OriginalNameAttribute,
+		sp.RoutingClassAttribute,
+		sp.EmitNormalizedPathAttribute,
+	}
+	if sp.StampProvenance {
+		candidates = append(candidates, provenanceProcessedAttribute, provenanceVersionAttribute)
+	}
+	
+	attrs := make([]string, 0, len(candidates))
+	for _, name := range candidates {
+		if name != "" {
+			attrs = append(attrs, name)
+		}
+	}
+	return attrs
+}
+
 var _ component.Config = (*Config)(nil)
\ No newline at end of file
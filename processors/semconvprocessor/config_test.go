@@ -5,8 +5,10 @@ package semconvprocessor
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 )
 
@@ -56,6 +58,26 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid config audit mode",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:           true,
+					Mode:              ModeAudit,
+					MarkNonConformant: true,
+					Rules: []OTTLRule{
+						{
+							ID:            "test",
+							Priority:      100,
+							Condition:     `true`,
+							OperationName: `"test"`,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid mode",
 			config: &Config{
@@ -74,7 +96,7 @@ func TestConfig_Validate(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			errMsg:  "invalid mode \"invalid\", must be 'enrich' or 'enforce'",
+			errMsg:  "invalid mode \"invalid\", must be 'enrich', 'enforce' or 'audit'",
 		},
 		{
 			name: "no rules",
@@ -242,83 +264,872 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
-	}
-	
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := tt.config.Validate()
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errMsg != "" {
-					assert.Contains(t, err.Error(), tt.errMsg)
-				}
-			} else {
-				assert.NoError(t, err)
-			}
-		})
-	}
-}
-
-func TestConfig_Implements(t *testing.T) {
-	cfg := &Config{}
-	// Verify that Config implements component.Config interface
-	var _ component.Config = cfg
-}
-
-func TestSpanProcessingConfig_DefaultValues(t *testing.T) {
-	sp := &SpanProcessingConfig{
-		Enabled: true,
-		Rules: []OTTLRule{
-			{
-				ID:            "test",
-				Priority:      100,
-				Condition:     `true`,
-				OperationName: `"test"`,
+		{
+			name: "name mappings without rules",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:      true,
+					NameMappings: map[string]string{"raw": "canonical"},
+				},
 			},
+			wantErr: false,
 		},
-	}
-	
-	err := sp.Validate()
-	assert.NoError(t, err)
-	
-	// Check defaults were set
-	assert.Equal(t, ModeEnrich, sp.Mode)
-	assert.Equal(t, "operation.name", sp.OperationNameAttribute)
-	assert.Equal(t, "operation.type", sp.OperationTypeAttribute)
-	assert.Equal(t, "name.original", sp.OriginalNameAttribute)
-}
-
-func TestSpanProcessingConfig_RuleSorting(t *testing.T) {
-	sp := &SpanProcessingConfig{
-		Enabled: true,
-		Mode:    ModeEnrich,
-		Rules: []OTTLRule{
-			{
-				ID:            "low_priority",
-				Priority:      1000,
-				Condition:     `true`,
-				OperationName: `"fallback"`,
+		{
+			name: "name mapping with empty operation name",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:      true,
+					NameMappings: map[string]string{"raw": ""},
+				},
 			},
-			{
-				ID:            "high_priority",
-				Priority:      100,
-				Condition:     `true`,
-				OperationName: `"specific"`,
+			wantErr: true,
+			errMsg:  "name_mappings entry \"raw\" has an empty operation name",
+		},
+		{
+			name: "benchmark export without benchmark",
+			config: &Config{
+				Enabled:             true,
+				BenchmarkExportPath: "/tmp/benchmark.json",
 			},
-			{
-				ID:            "medium_priority",
-				Priority:      500,
-				Condition:     `true`,
-				OperationName: `"medium"`,
+			wantErr: true,
+			errMsg:  "benchmark_export_path requires benchmark to be enabled",
+		},
+		{
+			name: "negative benchmark interval",
+			config: &Config{
+				Enabled:           true,
+				Benchmark:         true,
+				BenchmarkInterval: -time.Second,
 			},
+			wantErr: true,
+			errMsg:  "benchmark_interval must not be negative",
 		},
-	}
-	
-	err := sp.Validate()
-	assert.NoError(t, err)
-	
-	// Rules should be sorted by priority
-	assert.Equal(t, "high_priority", sp.Rules[0].ID)
-	assert.Equal(t, "medium_priority", sp.Rules[1].ID)
-	assert.Equal(t, "low_priority", sp.Rules[2].ID)
-}
\ No newline at end of file
+		{
+			name: "audit sample ratio out of range",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:          true,
+					Mode:             ModeAudit,
+					AuditSampleRatio: 1.5,
+					NameMappings:     map[string]string{"raw": "canonical"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "audit_sample_ratio must be between 0 and 1, got 1.5",
+		},
+		{
+			name: "negative max spans per batch",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:          true,
+					MaxSpansPerBatch: -1,
+					NameMappings:     map[string]string{"raw": "canonical"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "max_spans_per_batch must not be negative, got -1",
+		},
+		{
+			name: "resource attribute allowlist with empty name",
+			config: &Config{
+				Enabled:                    true,
+				ResourceAttributeAllowlist: []string{"service.name", ""},
+			},
+			wantErr: true,
+			errMsg:  "resource_attribute_allowlist contains an empty attribute name",
+		},
+		{
+			name: "known attributes with empty name",
+			config: &Config{
+				Enabled:         true,
+				KnownAttributes: []string{"app.tenant", ""},
+			},
+			wantErr: true,
+			errMsg:  "known_attributes contains an empty attribute name",
+		},
+		{
+			name: "operation name and candidates both set",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{
+							ID:                      "test",
+							Condition:               "true",
+							OperationName:           `"test"`,
+							OperationNameCandidates: []string{`"fallback"`},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule test sets both operation_name and operation_name_candidates",
+		},
+		{
+			name: "template and operation name both set",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{
+							ID:            "test",
+							Condition:     "true",
+							OperationName: `"test"`,
+							Template:      "{http.request.method} {http.route}",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule test sets both template and operation_name",
+		},
+		{
+			name: "unclosed template placeholder",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{
+							ID:        "test",
+							Condition: "true",
+							Template:  "{http.request.method} {http.route",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `rule test has invalid template "{http.request.method} {http.route": unclosed { at offset 22`,
+		},
+		{
+			name: "empty operation name candidate",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{
+							ID:                      "test",
+							Condition:               "true",
+							OperationNameCandidates: []string{`"test"`, ""},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule test has an empty expression in operation_name_candidates",
+		},
+		{
+			name: "destination normalizer with invalid pattern",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                true,
+					DestinationNormalizers: []KeyRewrite{{Pattern: `-partition-(\d+`}},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "destination_normalizers[0] has invalid pattern",
+		},
+		{
+			name: "destination normalizer with empty pattern",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                true,
+					DestinationNormalizers: []KeyRewrite{{Replacement: "x"}},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "destination_normalizers[0] has an empty pattern",
+		},
+		{
+			name: "min priority greater than max priority",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:     true,
+					MinPriority: func() *int { v := 500; return &v }(),
+					MaxPriority: func() *int { v := 100; return &v }(),
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "min_priority (500) must not be greater than max_priority (100)",
+		},
+		{
+			name: "rule referencing an unsupported context",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{ID: "test", Condition: `log.severity_text == "ERROR"`, OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule test references the log context, which is not available to span rules",
+		},
+		{
+			name: "rule using resource paths and context names inside strings",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{
+							ID:            "test",
+							Condition:     `resource.attributes["service.name"] == "checkout" and attributes["log.level"] != nil`,
+							OperationName: `Concat(["metric.", attributes["log.level"]], "")`,
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "metrics sample ratio out of range",
+			config: &Config{
+				Enabled:            true,
+				MetricsSampleRatio: -0.5,
+			},
+			wantErr: true,
+			errMsg:  "metrics_sample_ratio must be between 0 and 1, got -0.5",
+		},
+		{
+			name: "attribute synonym equal to canonical",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					AttributeSynonyms: []AttributeSynonyms{
+						{Canonical: "url.full", Synonyms: []string{"url.full"}},
+					},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `attribute_synonyms[0] (url.full) has an invalid synonym "url.full"`,
+		},
+		{
+			name: "attribute synonyms without synonyms",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					AttributeSynonyms: []AttributeSynonyms{
+						{Canonical: "url.full"},
+					},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "attribute_synonyms[0] (url.full) has no synonyms",
+		},
+		{
+			name: "normalized path attribute overwrites source",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                     true,
+					EmitNormalizedPathAttribute: "url.path",
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `emit_normalized_path_attribute must not overwrite the source attribute "url.path"`,
+		},
+		{
+			name: "negative min id digits",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:           true,
+					PathNormalization: PathNormalizationConfig{MinIDDigits: -1},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "path_normalization.min_id_digits must not be negative, got -1",
+		},
+		{
+			name: "invalid operation type conflict policy",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:               true,
+					OperationTypeConflict: "replace",
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `invalid operation_type_conflict "replace"`,
+		},
+		{
+			name: "invalid operation type precedence",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                 true,
+					OperationTypePrecedence: "newest",
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `invalid operation_type_precedence "newest"`,
+		},
+		{
+			name: "invalid scope rewrite pattern",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:       true,
+					ScopeRewrites: []KeyRewrite{{Pattern: `my.old.(lib`}},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "scope_rewrites[0] has invalid pattern",
+		},
+		{
+			name: "enforce mode without rules in the priority window",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:     true,
+					Mode:        ModeEnforce,
+					MinPriority: func() *int { v := 500; return &v }(),
+					Rules: []OTTLRule{
+						{ID: "test", Priority: 100, Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "enforce mode requires at least one enabled rule inside the priority window or a name mapping",
+		},
+		{
+			name: "enforce mode with only name mappings",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:      true,
+					Mode:         ModeEnforce,
+					MinPriority:  func() *int { v := 500; return &v }(),
+					NameMappings: map[string]string{"raw": "GET /health"},
+					Rules: []OTTLRule{
+						{ID: "test", Priority: 100, Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "enrich mode without rules in the priority window",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:     true,
+					Mode:        ModeEnrich,
+					MinPriority: func() *int { v := 500; return &v }(),
+					Rules: []OTTLRule{
+						{ID: "test", Priority: 100, Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "routing class map with empty class",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:         true,
+					RoutingClassMap: map[string]string{"http": ""},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `routing_class_map entry "http" has an empty class`,
+		},
+		{
+			name: "skip if attribute without key",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:         true,
+					SkipIfAttribute: AttributeMatch{Value: "false"},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "skip_if_attribute has a value but no key",
+		},
+		{
+			name: "unsupported semconv version",
+			config: &Config{
+				Enabled:        true,
+				SemconvVersion: "1.99",
+			},
+			wantErr: true,
+			errMsg:  `unsupported semconv_version "1.99", must be one of 1.24, 1.26, 1.27, 1.30, 1.32`,
+		},
+		{
+			name: "negative max stacktrace frames",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:         true,
+					ExceptionEvents: ExceptionEventsConfig{MaxStacktraceFrames: -1},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "exception_events.max_stacktrace_frames must not be negative, got -1",
+		},
+		{
+			name: "invalid generic name pattern",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                true,
+					EnrichSetNameIfGeneric: true,
+					GenericNamePattern:     "(",
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid generic_name_pattern",
+		},
+		{
+			name: "invalid mapping phase",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:      true,
+					MappingPhase: "during",
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid mapping_phase",
+		},
+		{
+			name: "empty drop condition",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:        true,
+					DropConditions: []string{`name == "ping"`, ""},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "drop_conditions[1] is empty",
+		},
+		{
+			name: "empty drop condition with span processing disabled",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					DropConditions: []string{""},
+				},
+			},
+			wantErr: true,
+			errMsg:  "drop_conditions[0] is empty",
+		},
+		{
+			name: "log rule without path group",
+			config: &Config{
+				Enabled: true,
+				LogProcessing: LogProcessingConfig{
+					Enabled: true,
+					Rules:   []LogRule{{ID: "access", Pattern: `(?P<method>[A-Z]+) \S+`}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule access pattern must have the named groups method and path",
+		},
+		{
+			name: "log processing without rules",
+			config: &Config{
+				Enabled:       true,
+				LogProcessing: LogProcessingConfig{Enabled: true},
+			},
+			wantErr: true,
+			errMsg:  "log_processing validation failed: at least one rule must be defined",
+		},
+		{
+			name: "invalid max regex input bytes",
+			config: &Config{
+				Enabled:            true,
+				MaxRegexInputBytes: -2,
+			},
+			wantErr: true,
+			errMsg:  "max_regex_input_bytes must be positive, or -1 to disable the limit, got -2",
+		},
+		{
+			name: "rule match window without benchmark or audit mode",
+			config: &Config{
+				Enabled:         true,
+				RuleMatchWindow: time.Minute,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Mode:    ModeEnforce,
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule_match_window requires benchmark or span_processing audit mode",
+		},
+		{
+			name: "negative path max depth",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:           true,
+					PathNormalization: PathNormalizationConfig{MaxDepth: -1},
+					Rules: []OTTLRule{
+						{ID: "test", Condition: "true", OperationName: `"test"`},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "path_normalization.max_depth must not be negative, got -1",
+		},
+		{
+			name: "schema migration target version not in schema file",
+			config: &Config{
+				Enabled: true,
+				SchemaMigration: SchemaMigrationConfig{
+					File:          "testdata/schema/semconv.yaml",
+					TargetVersion: "1.30.0",
+				},
+			},
+			wantErr: true,
+			errMsg:  `schema_migration.target_version "1.30.0" is not listed in the schema file`,
+		},
+		{
+			name: "schema migration file missing",
+			config: &Config{
+				Enabled:         true,
+				SchemaMigration: SchemaMigrationConfig{File: "testdata/schema/missing.yaml"},
+			},
+			wantErr: true,
+			errMsg:  "failed to read schema file",
+		},
+		{
+			name: "service classification invalid pattern",
+			config: &Config{
+				Enabled: true,
+				ServiceClassification: ServiceClassificationConfig{
+					Rules: []ServiceClassificationRule{{Pattern: "^payments-(", Label: "critical"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  `service_classification validation failed: rule at index 0 has invalid pattern "^payments-("`,
+		},
+		{
+			name: "service classification empty label",
+			config: &Config{
+				Enabled: true,
+				ServiceClassification: ServiceClassificationConfig{
+					Rules: []ServiceClassificationRule{{Pattern: "^payments-"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule at index 0 has empty label",
+		},
+		{
+			name: "span kind rule with invalid kind",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:       true,
+					SpanKindRules: []SpanKindRule{{Condition: "true", Kind: "frontend"}},
+					NameMappings:  map[string]string{"raw": "canonical"},
+				},
+			},
+			wantErr: true,
+			errMsg:  `span_kind_rules[0] has invalid kind "frontend"`,
+		},
+		{
+			name: "span kind rule with empty condition",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:       true,
+					SpanKindRules: []SpanKindRule{{Kind: "server"}},
+					NameMappings:  map[string]string{"raw": "canonical"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "span_kind_rules[0] has empty condition",
+		},
+		{
+			name: "invalid signal",
+			config: &Config{
+				Enabled: true,
+				Signals: []string{"traces", "spans"},
+			},
+			wantErr: true,
+			errMsg:  "invalid signal \"spans\" in signals, must be one of 'traces', 'metrics', 'logs' or 'profiles'",
+		},
+		{
+			name: "invalid match strategy",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:       true,
+					MatchStrategy: "best",
+					NameMappings:  map[string]string{"raw": "canonical"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid match_strategy \"best\", must be 'first' or 'all'",
+		},
+		{
+			name: "extra attribute with empty expression",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{
+							ID:              "test",
+							Priority:        100,
+							Condition:       `true`,
+							OperationName:   `"test"`,
+							ExtraAttributes: map[string]string{"http.route": ""},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule test has empty expression for extra attribute \"http.route\"",
+		},
+		{
+			name: "extra attribute with empty name",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Rules: []OTTLRule{
+						{
+							ID:              "test",
+							Priority:        100,
+							Condition:       `true`,
+							OperationName:   `"test"`,
+							ExtraAttributes: map[string]string{"": `"value"`},
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "rule test has an extra attribute with an empty name",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Implements(t *testing.T) {
+	cfg := &Config{}
+	// Verify that Config implements component.Config interface
+	var _ component.Config = cfg
+}
+
+func TestSpanProcessingConfig_DefaultValues(t *testing.T) {
+	sp := &SpanProcessingConfig{
+		Enabled: true,
+		Rules: []OTTLRule{
+			{
+				ID:            "test",
+				Priority:      100,
+				Condition:     `true`,
+				OperationName: `"test"`,
+			},
+		},
+	}
+	
+	err := sp.Validate()
+	assert.NoError(t, err)
+	
+	// Check defaults were set
+	assert.Equal(t, ModeEnrich, sp.Mode)
+	assert.Equal(t, "operation.name", sp.OperationNameAttribute)
+	assert.Equal(t, "operation.type", sp.OperationTypeAttribute)
+	assert.Equal(t, "name.original", sp.OriginalNameAttribute)
+}
+
+func TestSpanProcessingConfig_RuleSorting(t *testing.T) {
+	sp := &SpanProcessingConfig{
+		Enabled: true,
+		Mode:    ModeEnrich,
+		Rules: []OTTLRule{
+			{
+				ID:            "low_priority",
+				Priority:      1000,
+				Condition:     `true`,
+				OperationName: `"fallback"`,
+			},
+			{
+				ID:            "high_priority",
+				Priority:      100,
+				Condition:     `true`,
+				OperationName: `"specific"`,
+			},
+			{
+				ID:            "medium_priority",
+				Priority:      500,
+				Condition:     `true`,
+				OperationName: `"medium"`,
+			},
+		},
+	}
+	
+	err := sp.Validate()
+	assert.NoError(t, err)
+	
+	// Rules should be sorted by priority
+	assert.Equal(t, "high_priority", sp.Rules[0].ID)
+	assert.Equal(t, "medium_priority", sp.Rules[1].ID)
+	assert.Equal(t, "low_priority", sp.Rules[2].ID)
+}
+func TestSpanProcessingConfig_DisabledFunctions(t *testing.T) {
+	tests := []struct {
+		name     string
+		disabled []string
+		rule     OTTLRule
+		errMsg   string
+	}{
+		{
+			name:     "disabled function unused",
+			disabled: []string{"ParseSQL"},
+			rule: OTTLRule{
+				ID:            "http",
+				Condition:     `attributes["url.path"] != nil`,
+				OperationName: `NormalizePath(attributes["url.path"])`,
+			},
+		},
+		{
+			name:     "disabled function in operation_name",
+			disabled: []string{"NormalizePath"},
+			rule: OTTLRule{
+				ID:            "http",
+				Condition:     `attributes["url.path"] != nil`,
+				OperationName: `Concat(["GET", NormalizePath(attributes["url.path"])], " ")`,
+			},
+			errMsg: "rule http uses disabled function NormalizePath",
+		},
+		{
+			name:     "disabled function in condition",
+			disabled: []string{"IsMatch"},
+			rule: OTTLRule{
+				ID:            "match",
+				Condition:     `IsMatch(name, "^GET")`,
+				OperationName: `"get"`,
+			},
+			errMsg: "rule match uses disabled function IsMatch",
+		},
+		{
+			name:     "function name inside a string literal",
+			disabled: []string{"NormalizePath"},
+			rule: OTTLRule{
+				ID:            "literal",
+				Condition:     `true`,
+				OperationName: `"NormalizePath(x)"`,
+			},
+		},
+		{
+			name:     "function name inside a string literal after other text",
+			disabled: []string{"IsMatch"},
+			rule: OTTLRule{
+				ID:            "literal",
+				Condition:     `name == "retry IsMatch(x)"`,
+				OperationName: `Concat(["call", "(ParseSQL("], " ")`,
+			},
+		},
+		{
+			name:     "unknown function",
+			disabled: []string{"DoesNotExist"},
+			rule: OTTLRule{
+				ID:            "test",
+				Condition:     `true`,
+				OperationName: `"test"`,
+			},
+			errMsg: "disabled_functions contains unknown function \"DoesNotExist\"",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sp := &SpanProcessingConfig{
+				Enabled:           true,
+				DisabledFunctions: tt.disabled,
+				Rules:             []OTTLRule{tt.rule},
+			}
+			err := sp.Validate()
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
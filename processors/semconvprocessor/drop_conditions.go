@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// compileDropConditions parses the drop conditions, keeping their order
+func (sp *semconvProcessor) compileDropConditions() error {
+	for i, expr := range sp.config.SpanProcessing.DropConditions {
+		condition, err := sp.parser.ParseCondition(expr)
+		if err != nil {
+			return fmt.Errorf("failed to parse drop_conditions[%d]: %w", i, err)
+		}
+		sp.dropConditions = append(sp.dropConditions, condition)
+	}
+	return nil
+}
+
+// shouldDropSpan reports whether the span matches any drop condition. Spans are
+// never dropped in audit mode, which leaves the data untouched.
+func (sp *semconvProcessor) shouldDropSpan(ctx context.Context, span ptrace.Span, resource pcommon.Resource, scope pcommon.InstrumentationScope) bool {
+	if len(sp.dropConditions) == 0 || sp.config.SpanProcessing.Mode == ModeAudit {
+		return false
+	}
+	
+	tCtx := ottlspan.NewTransformContext(span, scope, resource, ptrace.NewScopeSpans(), ptrace.NewResourceSpans())
+	for _, condition := range sp.dropConditions {
+		matches, err := condition.Eval(ctx, tCtx)
+		if err != nil {
+			// A condition that cannot be evaluated must not lose data
+			sp.logger.Debug("drop condition evaluation error", zap.Error(err))
+			continue
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeIndex identifies a scope by its position in the batch
+type scopeIndex struct {
+	resource int
+	scope    int
+}
+
+// removeEmptySpanContainers removes the scopes whose spans were all dropped, and then
+// resources left without scopes by that, so dropping spans does not leave empty
+// containers behind. Containers that arrived empty are passed through.
+func removeEmptySpanContainers(td ptrace.Traces, emptied map[scopeIndex]bool) {
+	resource := -1
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		resource++
+		scope := -1
+		removed := false
+		rs.ScopeSpans().RemoveIf(func(ptrace.ScopeSpans) bool {
+			scope++
+			if emptied[scopeIndex{resource: resource, scope: scope}] {
+				removed = true
+				return true
+			}
+			return false
+		})
+		return removed && rs.ScopeSpans().Len() == 0
+	})
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadatatest"
+)
+
+func TestProcessTraces_DropConditions(t *testing.T) {
+	processor, tel := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			DropConditions: []string{
+				`attributes["url.path"] == "/healthz"`,
+				`name == "ping"`,
+			},
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	health := spans.AppendEmpty()
+	health.SetName("GET")
+	health.Attributes().PutStr("url.path", "/healthz")
+	users := spans.AppendEmpty()
+	users.SetName("GET")
+	users.Attributes().PutStr("url.path", "/users")
+	spans.AppendEmpty().SetName("ping")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Only the matching spans are removed; the rest is processed as usual
+	require.Equal(t, 1, result.SpanCount())
+	remaining := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "operation", remaining.Name())
+	path, _ := remaining.Attributes().Get("url.path")
+	assert.Equal(t, "/users", path.Str())
+
+	metadatatest.AssertEqualProcessorSemconvSpansDropped(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_DropConditionsEmptyScope(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:        true,
+			Mode:           ModeEnforce,
+			DropConditions: []string{`name == "ping"`},
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	healthScope := rs.ScopeSpans().AppendEmpty()
+	healthScope.Scope().SetName("health")
+	healthScope.Spans().AppendEmpty().SetName("ping")
+	healthScope.Spans().AppendEmpty().SetName("ping")
+	appScope := rs.ScopeSpans().AppendEmpty()
+	appScope.Scope().SetName("app")
+	appScope.Spans().AppendEmpty().SetName("checkout")
+
+	// A resource whose only span is dropped disappears as well
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("ping")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, result.ResourceSpans().Len())
+	scopes := result.ResourceSpans().At(0).ScopeSpans()
+	require.Equal(t, 1, scopes.Len())
+	assert.Equal(t, "app", scopes.At(0).Scope().Name())
+	assert.Equal(t, 1, scopes.At(0).Spans().Len())
+}
+
+func TestProcessTraces_DropConditionsKeepsArrivedEmptyContainers(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:        true,
+			Mode:           ModeEnforce,
+			DropConditions: []string{`name == "ping"`},
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.ScopeSpans().AppendEmpty().Scope().SetName("empty")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("ping")
+	traces.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("service.name", "idle")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Only the scope emptied by the drop is removed
+	require.Equal(t, 2, result.ResourceSpans().Len())
+	scopes := result.ResourceSpans().At(0).ScopeSpans()
+	require.Equal(t, 1, scopes.Len())
+	assert.Equal(t, "empty", scopes.At(0).Scope().Name())
+	serviceName, _ := result.ResourceSpans().At(1).Resource().Attributes().Get("service.name")
+	assert.Equal(t, "idle", serviceName.Str())
+}
+
+func TestProcessTraces_DropConditionsAuditMode(t *testing.T) {
+	processor, tel := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:        true,
+			Mode:           ModeAudit,
+			DropConditions: []string{`name == "ping"`},
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("ping")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Audit mode never changes the data
+	assert.Equal(t, 1, result.SpanCount())
+	_, err = tel.GetMetric("otelcol_processor_semconv_spans_dropped")
+	assert.Error(t, err)
+}
+
+func TestProcessTraces_DropConditionsSpanProcessingDisabled(t *testing.T) {
+	processor, tel := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			DropConditions: []string{`name == "ping"`},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("ping")
+	spans.AppendEmpty().SetName("checkout")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Drops do not depend on the rules being enabled
+	require.Equal(t, 1, result.SpanCount())
+	assert.Equal(t, "checkout", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	metadatatest.AssertEqualProcessorSemconvSpansDropped(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_DropConditionsIgnoreProcessingGates(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:          true,
+			Mode:             ModeEnforce,
+			OnlySampled:      true,
+			MaxSpansPerBatch: 1,
+			DropConditions:   []string{`name == "ping"`},
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	// Unsampled, so only_sampled leaves it alone, but it is still dropped
+	spans.AppendEmpty().SetName("ping")
+	sampled := spans.AppendEmpty()
+	sampled.SetName("checkout")
+	sampled.SetFlags(1)
+	// Past the cap, but still dropped
+	spans.AppendEmpty().SetName("ping")
+	spans.AppendEmpty().SetName("cart")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	remaining := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 2, remaining.Len())
+	assert.Equal(t, "operation", remaining.At(0).Name())
+	assert.Equal(t, "cart", remaining.At(1).Name())
+}
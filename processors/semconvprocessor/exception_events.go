@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Exception event and attributes rewritten by the exception_events options
+const (
+	exceptionEventName           = "exception"
+	exceptionTypeAttribute       = "exception.type"
+	exceptionStacktraceAttribute = "exception.stacktrace"
+)
+
+// normalizeExceptionEvents shortens the type and truncates the stacktrace of the span's
+// exception events, reporting whether any event changed
+func normalizeExceptionEvents(span ptrace.Span, cfg ExceptionEventsConfig) bool {
+	changed := false
+	events := span.Events()
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		if event.Name() != exceptionEventName {
+			continue
+		}
+		attrs := event.Attributes()
+
+		if cfg.ShortenType {
+			if exceptionType, exists := attrs.Get(exceptionTypeAttribute); exists && exceptionType.Type() == pcommon.ValueTypeStr {
+				if short := shortExceptionType(exceptionType.Str()); short != exceptionType.Str() {
+					exceptionType.SetStr(short)
+					changed = true
+				}
+			}
+		}
+
+		if stacktrace, exists := attrs.Get(exceptionStacktraceAttribute); exists && stacktrace.Type() == pcommon.ValueTypeStr {
+			if truncated := truncateStacktrace(stacktrace.Str(), cfg.MaxStacktraceFrames, cfg.MaxStacktraceBytes); truncated != stacktrace.Str() {
+				stacktrace.SetStr(truncated)
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// shortExceptionType strips the package or namespace from a fully-qualified type,
+// e.g. java.lang.IllegalStateException or ActiveRecord::RecordNotFound
+func shortExceptionType(exceptionType string) string {
+	if idx := strings.LastIndex(exceptionType, "::"); idx != -1 {
+		exceptionType = exceptionType[idx+2:]
+	}
+	if idx := strings.LastIndex(exceptionType, "."); idx != -1 && idx < len(exceptionType)-1 {
+		exceptionType = exceptionType[idx+1:]
+	}
+	return exceptionType
+}
+
+// truncateStacktrace keeps the message line plus maxFrames frames and at most maxBytes bytes, cut
+// on a character boundary. Zero disables the respective limit.
+func truncateStacktrace(stacktrace string, maxFrames, maxBytes int) string {
+	if maxFrames > 0 {
+		// The first line is the exception message, the frames follow it
+		lines := strings.SplitAfterN(stacktrace, "\n", maxFrames+2)
+		if len(lines) > maxFrames+1 && lines[maxFrames+1] != "" {
+			stacktrace = strings.TrimSuffix(strings.Join(lines[:maxFrames+1], ""), "\n")
+		}
+	}
+
+	if maxBytes > 0 && len(stacktrace) > maxBytes {
+		cut := maxBytes
+		for cut > 0 && !utf8.RuneStart(stacktrace[cut]) {
+			cut--
+		}
+		stacktrace = stacktrace[:cut]
+	}
+	return stacktrace
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestShortExceptionType(t *testing.T) {
+	tests := []struct {
+		exceptionType string
+		expected      string
+	}{
+		{exceptionType: "java.lang.IllegalStateException", expected: "IllegalStateException"},
+		{exceptionType: "System.InvalidOperationException", expected: "InvalidOperationException"},
+		{exceptionType: "ActiveRecord::RecordNotFound", expected: "RecordNotFound"},
+		{exceptionType: "*errors.errorString", expected: "errorString"},
+		{exceptionType: "com.example.Outer$Inner", expected: "Outer$Inner"},
+		{exceptionType: "ValueError", expected: "ValueError"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.exceptionType, func(t *testing.T) {
+			assert.Equal(t, tt.expected, shortExceptionType(tt.exceptionType))
+		})
+	}
+}
+
+func TestTruncateStacktrace(t *testing.T) {
+	stacktrace := "java.lang.IllegalStateException: boom\n" +
+		"\tat com.example.A.a(A.java:1)\n" +
+		"\tat com.example.B.b(B.java:2)\n" +
+		"\tat com.example.C.c(C.java:3)\n"
+
+	tests := []struct {
+		name      string
+		maxFrames int
+		maxBytes  int
+		expected  string
+	}{
+		{
+			name:      "frame limit",
+			maxFrames: 2,
+			expected:  "java.lang.IllegalStateException: boom\n\tat com.example.A.a(A.java:1)\n\tat com.example.B.b(B.java:2)",
+		},
+		{
+			name:      "fewer frames than the limit",
+			maxFrames: 3,
+			expected:  stacktrace,
+		},
+		{
+			name:     "byte limit",
+			maxBytes: 16,
+			expected: "java.lang.Illega",
+		},
+		{
+			name:     "no limits",
+			expected: stacktrace,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, truncateStacktrace(stacktrace, tt.maxFrames, tt.maxBytes))
+		})
+	}
+
+	// Multi-byte characters are not split
+	assert.Equal(t, "ab", truncateStacktrace("abé", 0, 3))
+}
+
+func TestProcessTraces_ExceptionEvents(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			ExceptionEvents: ExceptionEventsConfig{
+				MaxStacktraceFrames: 2,
+				ShortenType:         true,
+			},
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	var frames []string
+	for i := 0; i < 200; i++ {
+		frames = append(frames, "\tat com.example.Service.call(Service.java:42)")
+	}
+	stacktrace := "java.lang.IllegalStateException: boom\n" + strings.Join(frames, "\n")
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("request")
+	exception := span.Events().AppendEmpty()
+	exception.SetName("exception")
+	exception.Attributes().PutStr("exception.type", "java.lang.IllegalStateException")
+	exception.Attributes().PutStr("exception.stacktrace", stacktrace)
+	other := span.Events().AppendEmpty()
+	other.SetName("log")
+	other.Attributes().PutStr("exception.type", "java.lang.IllegalStateException")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	events := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events()
+
+	exceptionType, _ := events.At(0).Attributes().Get("exception.type")
+	assert.Equal(t, "IllegalStateException", exceptionType.Str())
+	truncated, _ := events.At(0).Attributes().Get("exception.stacktrace")
+	assert.Equal(t, "java.lang.IllegalStateException: boom\n"+frames[0]+"\n"+frames[1], truncated.Str())
+
+	// Other events are left alone
+	otherType, _ := events.At(1).Attributes().Get("exception.type")
+	assert.Equal(t, "java.lang.IllegalStateException", otherType.Str())
+}
@@ -8,8 +8,11 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/collector/processor/processorhelper/xprocessorhelper"
+	"go.opentelemetry.io/collector/processor/xprocessor"
 
 	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
 )
@@ -23,12 +26,13 @@ const (
 
 // NewFactory creates a new ProcessorFactory for the semconv processor
 func NewFactory() processor.Factory {
-	return processor.NewFactory(
+	return xprocessor.NewFactory(
 		component.MustNewType(typeStr),
 		createDefaultConfig,
-		processor.WithTraces(createTracesProcessor, stability),
-		processor.WithMetrics(createMetricsProcessor, stability),
-		processor.WithLogs(createLogsProcessor, stability),
+		xprocessor.WithTraces(createTracesProcessor, stability),
+		xprocessor.WithMetrics(createMetricsProcessor, stability),
+		xprocessor.WithLogs(createLogsProcessor, stability),
+		xprocessor.WithProfiles(createProfilesProcessor, stability),
 	)
 }
 
@@ -37,6 +41,67 @@ func createDefaultConfig() component.Config {
 	return &Config{}
 }
 
+// tracesCapabilities reports whether the traces processor modifies data so the
+// collector only clones batches when it has to. Capabilities are fixed when the
+// processor is built, so they are derived from the configuration.
+func tracesCapabilities(cfg *Config) consumer.Capabilities {
+	if !cfg.Enabled || !cfg.signalEnabled("traces") {
+		return consumer.Capabilities{MutatesData: false}
+	}
+	if len(cfg.ResourceAttributeAllowlist) > 0 || cfg.SchemaMigration.enabled() || cfg.ServiceClassification.enabled() {
+		return consumer.Capabilities{MutatesData: true}
+	}
+	if !cfg.SpanProcessing.Enabled {
+		// Drop conditions apply even when the rules are disabled
+		return consumer.Capabilities{
+			MutatesData: len(cfg.SpanProcessing.DropConditions) > 0 && cfg.SpanProcessing.Mode != ModeAudit,
+		}
+	}
+	if cfg.SpanProcessing.Mode == ModeAudit {
+		// Audit mode only writes to spans when asked to mark them or attach error events
+		return consumer.Capabilities{
+			MutatesData: cfg.SpanProcessing.MarkNonConformant || cfg.SpanProcessing.AttachErrorEvents,
+		}
+	}
+	return consumer.Capabilities{MutatesData: true}
+}
+
+// resourceOnlyCapabilities reports whether processing of metrics, logs and profiles
+// modifies data through the resource attribute allowlist or service classification.
+func resourceOnlyCapabilities(cfg *Config, signal string) consumer.Capabilities {
+	return consumer.Capabilities{
+		MutatesData: cfg.Enabled && cfg.signalEnabled(signal) && (len(cfg.ResourceAttributeAllowlist) > 0 || cfg.ServiceClassification.enabled()),
+	}
+}
+
+// metricsCapabilities reports whether the metrics processor modifies data; besides the
+// resource attribute allowlist, schema migration renames attributes
+func metricsCapabilities(cfg *Config) consumer.Capabilities {
+	if cfg.Enabled && cfg.signalEnabled("metrics") && cfg.SchemaMigration.enabled() {
+		return consumer.Capabilities{MutatesData: true}
+	}
+	return resourceOnlyCapabilities(cfg, "metrics")
+}
+
+// logsCapabilities reports whether the logs processor modifies data; besides the
+// resource attribute allowlist, log processing writes operation names and schema
+// migration renames attributes
+func logsCapabilities(cfg *Config) consumer.Capabilities {
+	if cfg.Enabled && cfg.signalEnabled("logs") && (cfg.LogProcessing.Enabled || cfg.SchemaMigration.enabled()) {
+		return consumer.Capabilities{MutatesData: true}
+	}
+	return resourceOnlyCapabilities(cfg, "logs")
+}
+
+// profilesCapabilities reports whether the profiles processor modifies data; besides the
+// resource attribute allowlist, schema migration renames resource attributes
+func profilesCapabilities(cfg *Config) consumer.Capabilities {
+	if cfg.Enabled && cfg.signalEnabled("profiles") && cfg.SchemaMigration.enabled() {
+		return consumer.Capabilities{MutatesData: true}
+	}
+	return resourceOnlyCapabilities(cfg, "profiles")
+}
+
 // createTracesProcessor creates a traces processor
 func createTracesProcessor(
 	ctx context.Context,
@@ -58,10 +123,12 @@ func createTracesProcessor(
 		cfg,
 		nextConsumer,
 		sp.processTraces,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
-		processorhelper.WithShutdown(func(context.Context) error {
+		processorhelper.WithCapabilities(tracesCapabilities(cfg.(*Config))),
+		processorhelper.WithStart(sp.start),
+		processorhelper.WithShutdown(func(ctx context.Context) error {
+			err := sp.shutdown(ctx)
 			telemetryBuilder.Shutdown()
-			return nil
+			return err
 		}),
 	)
 }
@@ -87,10 +154,12 @@ func createMetricsProcessor(
 		cfg,
 		nextConsumer,
 		sp.processMetrics,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
-		processorhelper.WithShutdown(func(context.Context) error {
+		processorhelper.WithCapabilities(metricsCapabilities(cfg.(*Config))),
+		processorhelper.WithStart(sp.start),
+		processorhelper.WithShutdown(func(ctx context.Context) error {
+			err := sp.shutdown(ctx)
 			telemetryBuilder.Shutdown()
-			return nil
+			return err
 		}),
 	)
 }
@@ -116,10 +185,44 @@ func createLogsProcessor(
 		cfg,
 		nextConsumer,
 		sp.processLogs,
-		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}),
-		processorhelper.WithShutdown(func(context.Context) error {
+		processorhelper.WithCapabilities(logsCapabilities(cfg.(*Config))),
+		processorhelper.WithStart(sp.start),
+		processorhelper.WithShutdown(func(ctx context.Context) error {
+			err := sp.shutdown(ctx)
+			telemetryBuilder.Shutdown()
+			return err
+		}),
+	)
+}
+
+
+// createProfilesProcessor creates a profiles processor
+func createProfilesProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	nextConsumer xconsumer.Profiles,
+) (xprocessor.Profiles, error) {
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	sp, err := newSemconvProcessor(set.Logger, cfg.(*Config), telemetryBuilder, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+	return xprocessorhelper.NewProfiles(
+		ctx,
+		set,
+		cfg,
+		nextConsumer,
+		sp.processProfiles,
+		xprocessorhelper.WithCapabilities(profilesCapabilities(cfg.(*Config))),
+		xprocessorhelper.WithStart(sp.start),
+		xprocessorhelper.WithShutdown(func(ctx context.Context) error {
+			err := sp.shutdown(ctx)
 			telemetryBuilder.Shutdown()
-			return nil
+			return err
 		}),
 	)
 }
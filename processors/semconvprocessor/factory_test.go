@@ -6,12 +6,18 @@ package semconvprocessor
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/processor/xprocessor"
+	"go.uber.org/goleak"
 )
 
 func TestNewFactory(t *testing.T) {
@@ -102,6 +108,263 @@ func TestCreateLogsProcessor(t *testing.T) {
 	assert.NotNil(t, processor)
 }
 
+func TestCreateProfilesProcessor(t *testing.T) {
+	factory := NewFactory().(xprocessor.Factory)
+	cfg := createDefaultConfig()
+	sink := new(consumertest.ProfilesSink)
+	
+	processor, err := factory.CreateProfiles(
+		context.Background(),
+		processortest.NewNopSettings(component.MustNewType("semconv")),
+		cfg,
+		sink,
+	)
+	require.NoError(t, err)
+	require.NotNil(t, processor)
+	require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+	
+	profiles := pprofile.NewProfiles()
+	profiles.ResourceProfiles().AppendEmpty().Resource().Attributes().PutStr("service.name", "checkout")
+	require.NoError(t, processor.ConsumeProfiles(context.Background(), profiles))
+	require.NoError(t, processor.Shutdown(context.Background()))
+	
+	// Profiles are passed through untouched
+	require.Len(t, sink.AllProfiles(), 1)
+	got := sink.AllProfiles()[0]
+	require.Equal(t, 1, got.ResourceProfiles().Len())
+	serviceName, ok := got.ResourceProfiles().At(0).Resource().Attributes().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "checkout", serviceName.Str())
+}
+
+func TestTracesCapabilities(t *testing.T) {
+	rules := []OTTLRule{
+		{
+			ID:            "test",
+			Priority:      100,
+			Condition:     `true`,
+			OperationName: `"test"`,
+		},
+	}
+	
+	tests := []struct {
+		name        string
+		config      *Config
+		mutatesData bool
+	}{
+		{
+			name:        "disabled",
+			config:      &Config{Enabled: false},
+			mutatesData: false,
+		},
+		{
+			name:        "span processing disabled",
+			config:      &Config{Enabled: true, Benchmark: true},
+			mutatesData: false,
+		},
+		{
+			name: "traces not in signals",
+			config: &Config{
+				Enabled:        true,
+				Signals:        []string{"metrics"},
+				SpanProcessing: SpanProcessingConfig{Enabled: true, Mode: ModeEnforce, Rules: rules},
+			},
+			mutatesData: false,
+		},
+		{
+			name: "enforce",
+			config: &Config{
+				Enabled:        true,
+				SpanProcessing: SpanProcessingConfig{Enabled: true, Mode: ModeEnforce, Rules: rules},
+			},
+			mutatesData: true,
+		},
+		{
+			name: "enrich",
+			config: &Config{
+				Enabled:        true,
+				SpanProcessing: SpanProcessingConfig{Enabled: true, Mode: ModeEnrich, Rules: rules},
+			},
+			mutatesData: true,
+		},
+		{
+			name: "audit",
+			config: &Config{
+				Enabled:        true,
+				SpanProcessing: SpanProcessingConfig{Enabled: true, Mode: ModeAudit, Rules: rules},
+			},
+			mutatesData: false,
+		},
+		{
+			name: "drop conditions without span processing",
+			config: &Config{
+				Enabled:        true,
+				SpanProcessing: SpanProcessingConfig{DropConditions: []string{`name == "ping"`}},
+			},
+			mutatesData: true,
+		},
+		{
+			name: "schema migration without span processing",
+			config: &Config{
+				Enabled:         true,
+				SchemaMigration: SchemaMigrationConfig{File: "testdata/schema/semconv.yaml"},
+			},
+			mutatesData: true,
+		},
+		{
+			name: "resource allowlist without span processing",
+			config: &Config{
+				Enabled:                    true,
+				ResourceAttributeAllowlist: []string{"service.name"},
+			},
+			mutatesData: true,
+		},
+		{
+			name: "audit marking non-conformant spans",
+			config: &Config{
+				Enabled:        true,
+				SpanProcessing: SpanProcessingConfig{Enabled: true, Mode: ModeAudit, MarkNonConformant: true, Rules: rules},
+			},
+			mutatesData: true,
+		},
+		{
+			name: "audit attaching error events",
+			config: &Config{
+				Enabled:        true,
+				SpanProcessing: SpanProcessingConfig{Enabled: true, Mode: ModeAudit, AttachErrorEvents: true, Rules: rules},
+			},
+			mutatesData: true,
+		},
+		{
+			name: "audit skips pre-rule rewrites",
+			config: &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                true,
+					Mode:                   ModeAudit,
+					UseAttributeAsName:     "span.name.override",
+					NormalizeHTTPMethod:    true,
+					NetToServerClient:      true,
+					DestinationNormalizers: []KeyRewrite{{Pattern: `-partition-\d+$`}},
+					Rules:                  rules,
+				},
+			},
+			mutatesData: false,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.config.Validate())
+			
+			processor, err := createTracesProcessor(
+				context.Background(),
+				processortest.NewNopSettings(component.MustNewType("semconv")),
+				tt.config,
+				consumertest.NewNop(),
+			)
+			require.NoError(t, err)
+			assert.Equal(t, tt.mutatesData, processor.Capabilities().MutatesData)
+		})
+	}
+}
+
+func TestResourceOnlyCapabilities(t *testing.T) {
+	assert.False(t, resourceOnlyCapabilities(&Config{Enabled: true}, "metrics").MutatesData)
+	assert.False(t, resourceOnlyCapabilities(&Config{ResourceAttributeAllowlist: []string{"service.name"}}, "metrics").MutatesData)
+	assert.True(t, resourceOnlyCapabilities(&Config{Enabled: true, ResourceAttributeAllowlist: []string{"service.name"}}, "metrics").MutatesData)
+	assert.False(t, resourceOnlyCapabilities(&Config{Enabled: true, Signals: []string{"traces"}, ResourceAttributeAllowlist: []string{"service.name"}}, "metrics").MutatesData)
+}
+
+func TestMetricsCapabilities(t *testing.T) {
+	schemaMigration := SchemaMigrationConfig{File: "testdata/schema/semconv.yaml"}
+	assert.False(t, metricsCapabilities(&Config{Enabled: true}).MutatesData)
+	assert.True(t, metricsCapabilities(&Config{Enabled: true, SchemaMigration: schemaMigration}).MutatesData)
+	assert.False(t, metricsCapabilities(&Config{Enabled: true, Signals: []string{"traces"}, SchemaMigration: schemaMigration}).MutatesData)
+	assert.True(t, metricsCapabilities(&Config{Enabled: true, ResourceAttributeAllowlist: []string{"service.name"}}).MutatesData)
+	assert.True(t, metricsCapabilities(&Config{Enabled: true, ServiceClassification: ServiceClassificationConfig{
+		Rules: []ServiceClassificationRule{{Pattern: "^payments-", Label: "critical"}},
+	}}).MutatesData)
+}
+
+func TestLogsCapabilities(t *testing.T) {
+	logProcessing := LogProcessingConfig{Enabled: true}
+	assert.False(t, logsCapabilities(&Config{Enabled: true}).MutatesData)
+	assert.True(t, logsCapabilities(&Config{Enabled: true, LogProcessing: logProcessing}).MutatesData)
+	assert.False(t, logsCapabilities(&Config{Enabled: true, Signals: []string{"traces"}, LogProcessing: logProcessing}).MutatesData)
+	assert.True(t, logsCapabilities(&Config{Enabled: true, ResourceAttributeAllowlist: []string{"service.name"}}).MutatesData)
+	assert.True(t, logsCapabilities(&Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: "testdata/schema/semconv.yaml"}}).MutatesData)
+}
+
+func TestProfilesCapabilities(t *testing.T) {
+	schemaMigration := SchemaMigrationConfig{File: "testdata/schema/semconv.yaml"}
+	assert.False(t, profilesCapabilities(&Config{Enabled: true}).MutatesData)
+	assert.True(t, profilesCapabilities(&Config{Enabled: true, SchemaMigration: schemaMigration}).MutatesData)
+	assert.False(t, profilesCapabilities(&Config{Enabled: true, Signals: []string{"traces"}, SchemaMigration: schemaMigration}).MutatesData)
+	assert.True(t, profilesCapabilities(&Config{Enabled: true, ResourceAttributeAllowlist: []string{"service.name"}}).MutatesData)
+}
+
+func TestBenchmarkFlush_Shutdown(t *testing.T) {
+	cfg := &Config{
+		Enabled:           true,
+		Benchmark:         true,
+		BenchmarkInterval: 10 * time.Millisecond,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "test",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"test"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	// Simulate repeated config reloads; every cycle must release its flush goroutine
+	for i := 0; i < 3; i++ {
+		processor, err := createTracesProcessor(
+			context.Background(),
+			processortest.NewNopSettings(component.MustNewType("semconv")),
+			cfg,
+			consumertest.NewNop(),
+		)
+		require.NoError(t, err)
+		require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+		
+		traces := ptrace.NewTraces()
+		traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+		require.NoError(t, processor.ConsumeTraces(context.Background(), traces))
+		
+		// Let the ticker fire at least once
+		time.Sleep(25 * time.Millisecond)
+		
+		require.NoError(t, processor.Shutdown(context.Background()))
+	}
+	
+	goleak.VerifyNone(t)
+}
+
+func TestBenchmarkFlush_ShutdownWithoutStart(t *testing.T) {
+	cfg := &Config{
+		Enabled:           true,
+		Benchmark:         true,
+		BenchmarkInterval: time.Second,
+	}
+	
+	processor, err := createTracesProcessor(
+		context.Background(),
+		processortest.NewNopSettings(component.MustNewType("semconv")),
+		cfg,
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	assert.NoError(t, processor.Shutdown(context.Background()))
+}
+
 func TestFactory_Stability(t *testing.T) {
 	// Verify that the stability level is consistent
 	assert.Equal(t, component.StabilityLevelAlpha, stability)
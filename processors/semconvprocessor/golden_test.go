@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+// updateGolden rewrites the expected.json files from the current processor output:
+//
+//	go test -run TestGolden -update-golden
+var updateGolden = flag.Bool("update-golden", false, "rewrite testdata/golden expected files")
+
+// goldenDir holds one directory per case with a config.yaml, an input.json and an expected.json
+const goldenDir = "testdata/golden"
+
+func TestGolden(t *testing.T) {
+	cases, err := os.ReadDir(goldenDir)
+	require.NoError(t, err)
+
+	for _, c := range cases {
+		if !c.IsDir() {
+			continue
+		}
+		dir := filepath.Join(goldenDir, c.Name())
+		t.Run(c.Name(), func(t *testing.T) {
+			cfg := loadGoldenConfig(t, filepath.Join(dir, "config.yaml"))
+
+			set := processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings
+			telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+			require.NoError(t, err)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, set)
+			require.NoError(t, err)
+
+			input := readGoldenTraces(t, filepath.Join(dir, "input.json"))
+			result, err := processor.processTraces(context.Background(), input)
+			require.NoError(t, err)
+
+			expectedPath := filepath.Join(dir, "expected.json")
+			if *updateGolden {
+				writeGoldenTraces(t, expectedPath, result)
+			}
+
+			expected := readGoldenTraces(t, expectedPath)
+			assert.JSONEq(t, string(marshalGoldenTraces(t, expected)), string(marshalGoldenTraces(t, result)))
+		})
+	}
+}
+
+// loadGoldenConfig unmarshals a processor config on top of the factory defaults and validates it
+func loadGoldenConfig(t *testing.T, path string) *Config {
+	t.Helper()
+	cm, err := confmaptest.LoadConf(path)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cm.Unmarshal(cfg))
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+// readGoldenTraces decodes an OTLP JSON file into traces
+func readGoldenTraces(t *testing.T, path string) ptrace.Traces {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	unmarshaler := &ptrace.JSONUnmarshaler{}
+	td, err := unmarshaler.UnmarshalTraces(data)
+	require.NoError(t, err)
+	return td
+}
+
+// marshalGoldenTraces encodes traces as OTLP JSON, so both sides of a comparison share one encoding
+func marshalGoldenTraces(t *testing.T, td ptrace.Traces) []byte {
+	t.Helper()
+	marshaler := &ptrace.JSONMarshaler{}
+	data, err := marshaler.MarshalTraces(td)
+	require.NoError(t, err)
+	return data
+}
+
+// writeGoldenTraces stores traces as indented OTLP JSON so golden diffs stay reviewable
+func writeGoldenTraces(t *testing.T, path string, td ptrace.Traces) {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, json.Indent(&buf, marshalGoldenTraces(t, td), "", "  "))
+	buf.WriteByte('\n')
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+}
@@ -26,14 +26,26 @@ type TelemetryBuilder struct {
 	meter                                     metric.Meter
 	mu                                        sync.Mutex
 	registrations                             []metric.Registration
+	ProcessorSemconvDistinctOperationTypes    metric.Int64Gauge
 	ProcessorSemconvErrors                    metric.Int64Counter
+	ProcessorSemconvOperationNameLength       metric.Int64Histogram
+	ProcessorSemconvOriginalNameLength        metric.Int64Histogram
 	ProcessorSemconvOriginalSpanNameCount     metric.Int64Gauge
 	ProcessorSemconvProcessingDuration        metric.Float64Histogram
 	ProcessorSemconvReducedSpanNameCount      metric.Int64Gauge
+	ProcessorSemconvRegexInputsSkipped        metric.Int64Counter
+	ProcessorSemconvResourceAttributesDropped metric.Int64Counter
+	ProcessorSemconvRuleCardinalityAbsorbed   metric.Int64Gauge
+	ProcessorSemconvRuleNeverMatched          metric.Int64Gauge
 	ProcessorSemconvSpanNamesEnforced         metric.Int64Counter
+	ProcessorSemconvSpansDropped              metric.Int64Counter
 	ProcessorSemconvSpansProcessed            metric.Int64Counter
+	ProcessorSemconvSpansSkippedOverLimit     metric.Int64Counter
+	ProcessorSemconvTypeConflicts             metric.Int64Counter
 	ProcessorSemconvUniqueOperationNamesTotal metric.Int64Counter
 	ProcessorSemconvUniqueSpanNamesTotal      metric.Int64Counter
+	ProcessorSemconvUnknownAttributes         metric.Int64Counter
+	ProcessorSemconvViolations                metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -65,12 +77,32 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ProcessorSemconvDistinctOperationTypes, err = builder.meter.Int64Gauge(
+		"otelcol_processor_semconv_distinct_operation_types",
+		metric.WithDescription("Number of distinct operation types on the spans of the last batch"),
+		metric.WithUnit("{types}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorSemconvErrors, err = builder.meter.Int64Counter(
 		"otelcol_processor_semconv_errors",
 		metric.WithDescription("Number of errors encountered during processing"),
 		metric.WithUnit("{errors}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvOperationNameLength, err = builder.meter.Int64Histogram(
+		"otelcol_processor_semconv_operation_name_length",
+		metric.WithDescription("Length of generated operation names (benchmark mode)"),
+		metric.WithUnit("{characters}"),
+		metric.WithExplicitBucketBoundaries([]float64{8, 16, 32, 64, 128, 256}...),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvOriginalNameLength, err = builder.meter.Int64Histogram(
+		"otelcol_processor_semconv_original_name_length",
+		metric.WithDescription("Length of span names before processing (benchmark mode)"),
+		metric.WithUnit("{characters}"),
+		metric.WithExplicitBucketBoundaries([]float64{8, 16, 32, 64, 128, 256}...),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorSemconvOriginalSpanNameCount, err = builder.meter.Int64Gauge(
 		"otelcol_processor_semconv_original_span_name_count",
 		metric.WithDescription("Number of unique span names before enforcement"),
@@ -90,18 +122,60 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{names}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvRegexInputsSkipped, err = builder.meter.Int64Counter(
+		"otelcol_processor_semconv_regex_inputs_skipped",
+		metric.WithDescription("Number of inputs user-supplied regular expressions were not applied to because they exceed max_regex_input_bytes"),
+		metric.WithUnit("{inputs}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvResourceAttributesDropped, err = builder.meter.Int64Counter(
+		"otelcol_processor_semconv_resource_attributes_dropped",
+		metric.WithDescription("Number of resource attributes removed because they are not in the allowlist"),
+		metric.WithUnit("{attributes}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvRuleCardinalityAbsorbed, err = builder.meter.Int64Gauge(
+		"otelcol_processor_semconv_rule_cardinality_absorbed",
+		metric.WithDescription("Number of unique span names each rule has mapped to an operation name (benchmark mode)"),
+		metric.WithUnit("{names}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvRuleNeverMatched, err = builder.meter.Int64Gauge(
+		"otelcol_processor_semconv_rule_never_matched",
+		metric.WithDescription("Number of rules that matched no span during the last rule match window (benchmark and audit modes)"),
+		metric.WithUnit("{rules}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorSemconvSpanNamesEnforced, err = builder.meter.Int64Counter(
 		"otelcol_processor_semconv_span_names_enforced",
 		metric.WithDescription("Number of span names changed to match semantic conventions"),
 		metric.WithUnit("{operations}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvSpansDropped, err = builder.meter.Int64Counter(
+		"otelcol_processor_semconv_spans_dropped",
+		metric.WithDescription("Number of spans removed because they matched a drop condition"),
+		metric.WithUnit("{spans}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorSemconvSpansProcessed, err = builder.meter.Int64Counter(
 		"otelcol_processor_semconv_spans_processed",
 		metric.WithDescription("Number of spans processed by the processor"),
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvSpansSkippedOverLimit, err = builder.meter.Int64Counter(
+		"otelcol_processor_semconv_spans_skipped_over_limit",
+		metric.WithDescription("Number of spans passed through unprocessed because their batch exceeded max_spans_per_batch"),
+		metric.WithUnit("{spans}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvTypeConflicts, err = builder.meter.Int64Counter(
+		"otelcol_processor_semconv_type_conflicts",
+		metric.WithDescription("Number of times a matching rule computed an operation type different from that of an earlier matching rule (all match strategy)"),
+		metric.WithUnit("{conflicts}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorSemconvUniqueOperationNamesTotal, err = builder.meter.Int64Counter(
 		"otelcol_processor_semconv_unique_operation_names_total",
 		metric.WithDescription("Total number of unique operation names discovered"),
@@ -114,5 +188,17 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{names}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvUnknownAttributes, err = builder.meter.Int64Counter(
+		"otelcol_processor_semconv_unknown_attributes",
+		metric.WithDescription("Number of span attributes whose key is not a known semantic convention attribute (benchmark and audit modes)"),
+		metric.WithUnit("{attributes}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorSemconvViolations, err = builder.meter.Int64Counter(
+		"otelcol_processor_semconv_violations",
+		metric.WithDescription("Number of spans whose name does not conform to the name a rule would enforce (audit mode)"),
+		metric.WithUnit("{spans}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -19,17 +19,38 @@ func TestSetupTelemetry(t *testing.T) {
 	tb, err := metadata.NewTelemetryBuilder(testTel.NewTelemetrySettings())
 	require.NoError(t, err)
 	defer tb.Shutdown()
+	tb.ProcessorSemconvDistinctOperationTypes.Record(context.Background(), 1)
 	tb.ProcessorSemconvErrors.Add(context.Background(), 1)
+	tb.ProcessorSemconvOperationNameLength.Record(context.Background(), 1)
+	tb.ProcessorSemconvOriginalNameLength.Record(context.Background(), 1)
 	tb.ProcessorSemconvOriginalSpanNameCount.Record(context.Background(), 1)
 	tb.ProcessorSemconvProcessingDuration.Record(context.Background(), 1)
 	tb.ProcessorSemconvReducedSpanNameCount.Record(context.Background(), 1)
+	tb.ProcessorSemconvRegexInputsSkipped.Add(context.Background(), 1)
+	tb.ProcessorSemconvResourceAttributesDropped.Add(context.Background(), 1)
+	tb.ProcessorSemconvRuleCardinalityAbsorbed.Record(context.Background(), 1)
+	tb.ProcessorSemconvRuleNeverMatched.Record(context.Background(), 1)
 	tb.ProcessorSemconvSpanNamesEnforced.Add(context.Background(), 1)
+	tb.ProcessorSemconvSpansDropped.Add(context.Background(), 1)
 	tb.ProcessorSemconvSpansProcessed.Add(context.Background(), 1)
+	tb.ProcessorSemconvSpansSkippedOverLimit.Add(context.Background(), 1)
+	tb.ProcessorSemconvTypeConflicts.Add(context.Background(), 1)
 	tb.ProcessorSemconvUniqueOperationNamesTotal.Add(context.Background(), 1)
 	tb.ProcessorSemconvUniqueSpanNamesTotal.Add(context.Background(), 1)
+	tb.ProcessorSemconvUnknownAttributes.Add(context.Background(), 1)
+	tb.ProcessorSemconvViolations.Add(context.Background(), 1)
+	AssertEqualProcessorSemconvDistinctOperationTypes(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessorSemconvErrors(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvOperationNameLength(t, testTel,
+		[]metricdata.HistogramDataPoint[int64]{{}}, metricdatatest.IgnoreValue(),
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvOriginalNameLength(t, testTel,
+		[]metricdata.HistogramDataPoint[int64]{{}}, metricdatatest.IgnoreValue(),
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessorSemconvOriginalSpanNameCount(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -39,18 +60,45 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualProcessorSemconvReducedSpanNameCount(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvRegexInputsSkipped(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvResourceAttributesDropped(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvRuleCardinalityAbsorbed(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvRuleNeverMatched(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessorSemconvSpanNamesEnforced(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvSpansDropped(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessorSemconvSpansProcessed(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvSpansSkippedOverLimit(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvTypeConflicts(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessorSemconvUniqueOperationNamesTotal(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
 	AssertEqualProcessorSemconvUniqueSpanNamesTotal(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvUnknownAttributes(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+	AssertEqualProcessorSemconvViolations(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 
 	require.NoError(t, testTel.Shutdown(context.Background()))
 }
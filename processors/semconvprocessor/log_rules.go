@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// compiledLogRule is a LogRule with its pattern compiled
+type compiledLogRule struct {
+	id     string
+	regex  *regexp.Regexp
+	method int // Index of the method group
+	path   int // Index of the path group
+}
+
+// compileLogRules compiles the log rules, keeping their order
+func compileLogRules(rules []LogRule) ([]compiledLogRule, error) {
+	compiled := make([]compiledLogRule, 0, len(rules))
+	for _, rule := range rules {
+		regex, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s has invalid pattern %q: %w", rule.ID, rule.Pattern, err)
+		}
+		method, path := regex.SubexpIndex("method"), regex.SubexpIndex("path")
+		if method < 0 || path < 0 {
+			return nil, fmt.Errorf("rule %s pattern must have the named groups method and path", rule.ID)
+		}
+		compiled = append(compiled, compiledLogRule{id: rule.ID, regex: regex, method: method, path: path})
+	}
+	return compiled, nil
+}
+
+// logOperationName derives "METHOD /normalized/path" from the body with the first
+// matching rule. ok is false when no rule matches.
+func logOperationName(body string, rules []compiledLogRule, paths *pathNormalizer) (string, bool) {
+	for _, rule := range rules {
+		match := rule.regex.FindStringSubmatch(body)
+		if match == nil || match[rule.method] == "" || match[rule.path] == "" {
+			continue
+		}
+		return strings.ToUpper(match[rule.method]) + " " + paths.normalize(match[rule.path]), true
+	}
+	return "", false
+}
+
+// processLogRecord writes the operation name derived from the record's body, unless
+// the record already has one
+func (sp *semconvProcessor) processLogRecord(ctx context.Context, record plog.LogRecord) {
+	attribute := sp.config.LogProcessing.OperationNameAttribute
+	if _, exists := record.Attributes().Get(attribute); exists {
+		return
+	}
+	body := record.Body().AsString()
+	if !sp.regexes.allow(ctx, body) {
+		return
+	}
+	if operationName, ok := logOperationName(body, sp.logRules, sp.logPaths); ok {
+		record.Attributes().PutStr(attribute, operationName)
+	}
+}
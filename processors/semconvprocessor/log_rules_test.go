@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+// requestLinePattern matches the request line of a common access log
+const requestLinePattern = `"?(?P<method>[A-Z]+) (?P<path>/\S*) HTTP/[0-9.]+`
+
+func TestLogOperationName(t *testing.T) {
+	rules, err := compileLogRules([]LogRule{{ID: "access", Pattern: requestLinePattern}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "request line",
+			body:     "GET /users/123 HTTP/1.1",
+			expected: "GET /users/{id}",
+			ok:       true,
+		},
+		{
+			name:     "combined log format",
+			body:     `10.0.0.1 - - [10/Oct/2024:13:55:36 +0000] "POST /orders/9f1c2e4a-5b6d-4e7f-8a9b-0c1d2e3f4a5b/items?page=2 HTTP/2.0" 201 512`,
+			expected: "POST /orders/{id}/items",
+			ok:       true,
+		},
+		{
+			name: "no request line",
+			body: "connection reset by peer",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			operationName, ok := logOperationName(tt.body, rules, defaultPathNormalizer)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, operationName)
+		})
+	}
+}
+
+func TestProcessLogs_LogProcessing(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		LogProcessing: LogProcessingConfig{
+			Enabled: true,
+			Rules:   []LogRule{{ID: "access", Pattern: requestLinePattern}},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr("GET /users/123 HTTP/1.1")
+	existing := records.AppendEmpty()
+	existing.Body().SetStr("GET /users/456 HTTP/1.1")
+	existing.Attributes().PutStr("operation.name", "get user")
+	records.AppendEmpty().Body().SetStr("worker started")
+
+	result, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	records = result.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+
+	operationName, exists := records.At(0).Attributes().Get("operation.name")
+	require.True(t, exists)
+	assert.Equal(t, "GET /users/{id}", operationName.Str())
+
+	// An existing operation name is kept
+	operationName, _ = records.At(1).Attributes().Get("operation.name")
+	assert.Equal(t, "get user", operationName.Str())
+
+	// Bodies without a request line are left alone
+	_, exists = records.At(2).Attributes().Get("operation.name")
+	assert.False(t, exists)
+}
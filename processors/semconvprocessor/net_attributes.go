@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// attributeRename moves the value of a deprecated attribute to its replacement
+type attributeRename struct {
+	From string
+	To   string
+}
+
+// netRenames apply to every span regardless of kind
+var netRenames = []attributeRename{
+	{From: "net.sock.peer.addr", To: "network.peer.address"},
+	{From: "net.sock.peer.port", To: "network.peer.port"},
+	{From: "net.sock.host.addr", To: "network.local.address"},
+	{From: "net.sock.host.port", To: "network.local.port"},
+	{From: "net.protocol.name", To: "network.protocol.name"},
+	{From: "net.protocol.version", To: "network.protocol.version"},
+}
+
+// netClientRenames apply to client and producer spans, where the peer is the server
+var netClientRenames = []attributeRename{
+	{From: "net.peer.name", To: "server.address"},
+	{From: "net.peer.port", To: "server.port"},
+}
+
+// netServerRenames apply to server and consumer spans, where the host is the server
+// and the peer is the client
+var netServerRenames = []attributeRename{
+	{From: "net.host.name", To: "server.address"},
+	{From: "net.host.port", To: "server.port"},
+	{From: "net.peer.name", To: "client.address"},
+	{From: "net.peer.port", To: "client.port"},
+}
+
+// migrateNetAttributes converts deprecated net.* attributes to their server.*,
+// client.* and network.* replacements. Whether net.peer.* describes the server
+// or the client depends on the span kind; for internal and unspecified spans
+// only the kind-independent attributes are converted. It reports whether any
+// attribute was converted.
+func migrateNetAttributes(span ptrace.Span) bool {
+	attrs := span.Attributes()
+	modified := renameAttributes(attrs, netRenames)
+	
+	switch span.Kind() {
+	case ptrace.SpanKindClient, ptrace.SpanKindProducer:
+		modified = renameAttributes(attrs, netClientRenames) || modified
+	case ptrace.SpanKindServer, ptrace.SpanKindConsumer:
+		modified = renameAttributes(attrs, netServerRenames) || modified
+	}
+	return modified
+}
+
+// migrateLinkNetAttributes converts deprecated net.* attributes on span links.
+// The kind of the linked span is unknown, so only the kind-independent
+// attributes are converted.
+func migrateLinkNetAttributes(span ptrace.Span) bool {
+	modified := false
+	links := span.Links()
+	for i := 0; i < links.Len(); i++ {
+		modified = renameAttributes(links.At(i).Attributes(), netRenames) || modified
+	}
+	return modified
+}
+
+// renameAttributes moves each From attribute to To as one simultaneous substitution,
+// so A→B, B→C moves A to B and B to C rather than A to C. A To attribute that stays in
+// place wins: a From with the same value is removed, and a conflicting one is kept,
+// since dropping it would lose data. It reports whether any attribute was renamed or removed.
+func renameAttributes(attrs pcommon.Map, renames []attributeRename) bool {
+	moving := make(map[string]bool, len(renames))
+	for _, rename := range renames {
+		if _, exists := attrs.Get(rename.From); exists {
+			moving[rename.From] = true
+		}
+	}
+	if len(moving) == 0 {
+		return false
+	}
+	
+	// A kept attribute stays in place and may occupy the target of another rename,
+	// so conflicts are resolved until none is left
+	var moves []attributeRename
+	for resolved := false; !resolved; {
+		resolved = true
+		moves = moves[:0]
+		claimed := make(map[string]pcommon.Value)
+		for _, rename := range renames {
+			if !moving[rename.From] {
+				continue
+			}
+			value, _ := attrs.Get(rename.From)
+			target, occupied := claimed[rename.To]
+			if !occupied && !moving[rename.To] {
+				target, occupied = attrs.Get(rename.To)
+			}
+			switch {
+			case !occupied:
+				claimed[rename.To] = value
+				moves = append(moves, rename)
+			case !target.Equal(value):
+				delete(moving, rename.From)
+				resolved = false
+			}
+		}
+	}
+	
+	// Copy the values out first, since a target can be the source of another rename
+	values := make([]pcommon.Value, len(moves))
+	targets := make(map[string]bool, len(moves))
+	for i, move := range moves {
+		value, _ := attrs.Get(move.From)
+		values[i] = pcommon.NewValueEmpty()
+		value.CopyTo(values[i])
+		targets[move.To] = true
+	}
+	for i, move := range moves {
+		values[i].CopyTo(attrs.PutEmpty(move.To))
+		if !targets[move.From] {
+			attrs.Remove(move.From)
+		}
+	}
+	
+	// Sources whose target already holds the same value are duplicates
+	for _, rename := range renames {
+		if moving[rename.From] && !targets[rename.From] {
+			attrs.Remove(rename.From)
+		}
+	}
+	return len(moving) > 0
+}
@@ -0,0 +1,309 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestMigrateNetAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     ptrace.SpanKind
+		input    map[string]any
+		expected map[string]any
+	}{
+		{
+			name: "client span",
+			kind: ptrace.SpanKindClient,
+			input: map[string]any{
+				"net.peer.name":      "api.example.com",
+				"net.peer.port":      int64(443),
+				"net.sock.peer.addr": "10.0.0.1",
+			},
+			expected: map[string]any{
+				"server.address":       "api.example.com",
+				"server.port":          int64(443),
+				"network.peer.address": "10.0.0.1",
+			},
+		},
+		{
+			name: "server span",
+			kind: ptrace.SpanKindServer,
+			input: map[string]any{
+				"net.host.name": "shop.example.com",
+				"net.host.port": int64(8080),
+				"net.peer.name": "203.0.113.7",
+				"net.peer.port": int64(52100),
+			},
+			expected: map[string]any{
+				"server.address": "shop.example.com",
+				"server.port":    int64(8080),
+				"client.address": "203.0.113.7",
+				"client.port":    int64(52100),
+			},
+		},
+		{
+			name: "internal span keeps kind-dependent attributes",
+			kind: ptrace.SpanKindInternal,
+			input: map[string]any{
+				"net.peer.name":     "api.example.com",
+				"net.protocol.name": "http",
+			},
+			expected: map[string]any{
+				"net.peer.name":         "api.example.com",
+				"network.protocol.name": "http",
+			},
+		},
+		{
+			name: "existing replacement with the same value wins",
+			kind: ptrace.SpanKindClient,
+			input: map[string]any{
+				"net.peer.name":  "api.example.com",
+				"server.address": "api.example.com",
+			},
+			expected: map[string]any{
+				"server.address": "api.example.com",
+			},
+		},
+		{
+			name: "conflicting deprecated attribute is kept",
+			kind: ptrace.SpanKindClient,
+			input: map[string]any{
+				"net.peer.name":  "old.example.com",
+				"server.address": "new.example.com",
+			},
+			expected: map[string]any{
+				"net.peer.name":  "old.example.com",
+				"server.address": "new.example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := ptrace.NewSpan()
+			span.SetKind(tt.kind)
+			require.NoError(t, span.Attributes().FromRaw(tt.input))
+
+			migrateNetAttributes(span)
+
+			assert.Equal(t, tt.expected, span.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestRenameAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		renames  []attributeRename
+		input    map[string]any
+		expected map[string]any
+		modified bool
+	}{
+		{
+			name:     "chained renames are applied simultaneously",
+			renames:  []attributeRename{{From: "a", To: "b"}, {From: "b", To: "c"}},
+			input:    map[string]any{"a": "1", "b": "2"},
+			expected: map[string]any{"b": "1", "c": "2"},
+			modified: true,
+		},
+		{
+			name:     "swap",
+			renames:  []attributeRename{{From: "a", To: "b"}, {From: "b", To: "a"}},
+			input:    map[string]any{"a": "1", "b": "2"},
+			expected: map[string]any{"a": "2", "b": "1"},
+			modified: true,
+		},
+		{
+			name:     "kept conflict blocks the rename into it",
+			renames:  []attributeRename{{From: "a", To: "b"}, {From: "b", To: "c"}},
+			input:    map[string]any{"a": "1", "b": "2", "c": "3"},
+			expected: map[string]any{"a": "1", "b": "2", "c": "3"},
+			modified: false,
+		},
+		{
+			name:     "nothing to rename",
+			renames:  []attributeRename{{From: "a", To: "b"}},
+			input:    map[string]any{"c": "1"},
+			expected: map[string]any{"c": "1"},
+			modified: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			require.NoError(t, attrs.FromRaw(tt.input))
+
+			assert.Equal(t, tt.modified, renameAttributes(attrs, tt.renames))
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func TestProcessTraces_NetToServerClient(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:           true,
+			Mode:              ModeEnforce,
+			NetToServerClient: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "client",
+					Priority:      100,
+					SpanKind:      []string{"client"},
+					Condition:     `attributes["server.address"] != nil`,
+					OperationName: `Concat(["call", attributes["server.address"]], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("HTTP GET")
+	span.SetKind(ptrace.SpanKindClient)
+	span.Attributes().PutStr("net.peer.name", "api.example.com")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Rules see the migrated attribute
+	resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "call api.example.com", resultSpan.Name())
+	_, exists := resultSpan.Attributes().Get("net.peer.name")
+	assert.False(t, exists)
+}
+
+func TestProcessTraces_ProcessLinkAttributes(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:               true,
+			Mode:                  ModeEnrich,
+			NetToServerClient:     true,
+			ProcessLinkAttributes: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "test",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"test"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	spanID := pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("process batch")
+	span.SetKind(ptrace.SpanKindConsumer)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(traceID)
+	link.SetSpanID(spanID)
+	link.Attributes().PutStr("net.sock.peer.addr", "10.0.0.1")
+	link.Attributes().PutStr("net.peer.name", "broker.example.com")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	resultLink := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Links().At(0)
+	assert.Equal(t, traceID, resultLink.TraceID())
+	assert.Equal(t, spanID, resultLink.SpanID())
+
+	// The linked span's kind is unknown, so net.peer.name is left alone
+	assert.Equal(t, map[string]any{
+		"network.peer.address": "10.0.0.1",
+		"net.peer.name":        "broker.example.com",
+	}, resultLink.Attributes().AsRaw())
+}
+
+func TestProcessTraces_MappingPhase(t *testing.T) {
+	tests := []struct {
+		phase    MappingPhase
+		expected string
+	}{
+		// Rules see the migrated server.address
+		{phase: MappingPhaseBefore, expected: "call api.example.com"},
+		// Rules see the net.peer.name the span arrived with
+		{phase: MappingPhaseAfter, expected: "legacy call api.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phase), func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:           true,
+					Mode:              ModeEnforce,
+					NetToServerClient: true,
+					MappingPhase:      tt.phase,
+					Rules: []OTTLRule{
+						{
+							ID:            "current",
+							Priority:      100,
+							Condition:     `attributes["server.address"] != nil`,
+							OperationName: `Concat(["call", attributes["server.address"]], " ")`,
+						},
+						{
+							ID:            "legacy",
+							Priority:      200,
+							Condition:     `attributes["net.peer.name"] != nil`,
+							OperationName: `Concat(["legacy call", attributes["net.peer.name"]], " ")`,
+						},
+					},
+				},
+			}
+			require.NoError(t, cfg.Validate())
+
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("HTTP GET")
+			span.SetKind(ptrace.SpanKindClient)
+			span.Attributes().PutStr("net.peer.name", "api.example.com")
+
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expected, resultSpan.Name())
+
+			// The attributes are migrated in either phase
+			_, exists := resultSpan.Attributes().Get("net.peer.name")
+			assert.False(t, exists)
+			serverAddress, _ := resultSpan.Attributes().Get("server.address")
+			assert.Equal(t, "api.example.com", serverAddress.Str())
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// operationTemplate is a rule template such as "{http.request.method} {http.route}",
+// a simpler alternative to an OTTL operation_name expression
+type operationTemplate struct {
+	parts []templatePart
+}
+
+// templatePart is either literal text or a span attribute placeholder
+type templatePart struct {
+	literal string
+	key     string // Attribute key of a placeholder, empty for literal text
+}
+
+// parseOperationTemplate splits a template into literal text and {attribute} placeholders
+func parseOperationTemplate(template string) (*operationTemplate, error) {
+	t := &operationTemplate{}
+	rest := template
+	for rest != "" {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			t.parts = append(t.parts, templatePart{literal: rest})
+			break
+		}
+		if rest[open] == '}' {
+			return nil, fmt.Errorf("unexpected } at offset %d", len(template)-len(rest)+open)
+		}
+		if open > 0 {
+			t.parts = append(t.parts, templatePart{literal: rest[:open]})
+		}
+
+		end := strings.IndexAny(rest[open+1:], "{}")
+		if end < 0 || rest[open+1+end] == '{' {
+			return nil, fmt.Errorf("unclosed { at offset %d", len(template)-len(rest)+open)
+		}
+		key := strings.TrimSpace(rest[open+1 : open+1+end])
+		if key == "" {
+			return nil, errors.New("empty placeholder {}")
+		}
+		t.parts = append(t.parts, templatePart{key: key})
+		rest = rest[open+1+end+1:]
+	}
+	return t, nil
+}
+
+// render interpolates the span attributes. Missing attributes render as empty, and the
+// separator after an empty placeholder is dropped when the text before it already ends in
+// whitespace, so "{a} {b} {c}" without b gives "A C" rather than "A  C". The result is
+// trimmed; whitespace inside attribute values is kept.
+func (t *operationTemplate) render(attrs pcommon.Map) string {
+	var b strings.Builder
+	afterEmpty := false
+	for _, part := range t.parts {
+		if part.key == "" {
+			literal := part.literal
+			if afterEmpty && endsInSpace(b.String()) {
+				literal = strings.TrimLeftFunc(literal, unicode.IsSpace)
+			}
+			b.WriteString(literal)
+			afterEmpty = false
+			continue
+		}
+		value, ok := attrs.Get(part.key)
+		if !ok || value.AsString() == "" {
+			afterEmpty = true
+			continue
+		}
+		b.WriteString(value.AsString())
+		afterEmpty = false
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// endsInSpace reports whether s is empty or ends in whitespace
+func endsInSpace(s string) bool {
+	last, _ := utf8.DecodeLastRuneInString(s)
+	return s == "" || unicode.IsSpace(last)
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestOperationTemplate_Render(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("http.request.method", "GET")
+	attrs.PutStr("http.route", "/users/{id}")
+	attrs.PutInt("http.response.status_code", 200)
+	attrs.PutStr("url.path", "/a  b\tc")
+	attrs.PutStr("empty", "")
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "present attributes",
+			template: "{http.request.method} {http.route}",
+			expected: "GET /users/{id}",
+		},
+		{
+			name:     "literal text and non-string values",
+			template: "HTTP {http.request.method} -> {http.response.status_code}",
+			expected: "HTTP GET -> 200",
+		},
+		{
+			name:     "missing attribute in the middle",
+			template: "{http.request.method} {url.scheme} {http.route}",
+			expected: "GET /users/{id}",
+		},
+		{
+			name:     "missing trailing attribute",
+			template: "{http.request.method} {missing}",
+			expected: "GET",
+		},
+		{
+			name:     "all attributes missing",
+			template: "{db.system.name} {db.operation.name}",
+			expected: "",
+		},
+		{
+			name:     "whitespace inside values is kept",
+			template: "{http.request.method} {url.path}",
+			expected: "GET /a  b\tc",
+		},
+		{
+			name:     "missing leading attribute",
+			template: "{url.scheme} {http.request.method} {http.route}",
+			expected: "GET /users/{id}",
+		},
+		{
+			name:     "empty attribute in the middle",
+			template: "{http.request.method} {empty} {http.route}",
+			expected: "GET /users/{id}",
+		},
+		{
+			name:     "missing attribute next to literal text",
+			template: "HTTP {url.scheme} -> {http.response.status_code}",
+			expected: "HTTP -> 200",
+		},
+		{
+			name:     "placeholder whitespace",
+			template: "{ http.request.method }",
+			expected: "GET",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := parseOperationTemplate(tt.template)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, template.render(attrs))
+		})
+	}
+}
+
+func TestParseOperationTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		errMsg   string
+	}{
+		{
+			name:     "unclosed placeholder",
+			template: "{http.request.method",
+			errMsg:   "unclosed { at offset 0",
+		},
+		{
+			name:     "nested placeholder",
+			template: "{http.{route}}",
+			errMsg:   "unclosed { at offset 0",
+		},
+		{
+			name:     "stray closing brace",
+			template: "GET }",
+			errMsg:   "unexpected } at offset 4",
+		},
+		{
+			name:     "empty placeholder",
+			template: "GET {}",
+			errMsg:   "empty placeholder {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseOperationTemplate(tt.template)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestProcessTraces_Template(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:        "http_server",
+					Priority:  100,
+					Condition: `attributes["http.request.method"] != nil`,
+					Template:  "{http.request.method} {http.route}",
+				},
+				{
+					ID:        "fallback",
+					Priority:  200,
+					Condition: "true",
+					Template:  "{rpc.method}",
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+
+	routed := spans.AppendEmpty()
+	routed.SetName("GET /users/42")
+	routed.Attributes().PutStr("http.request.method", "GET")
+	routed.Attributes().PutStr("http.route", "/users/{id}")
+
+	// Without a route the method alone is left after trimming
+	unrouted := spans.AppendEmpty()
+	unrouted.SetName("POST /login")
+	unrouted.Attributes().PutStr("http.request.method", "POST")
+
+	// A template that renders empty leaves the span alone
+	blank := spans.AppendEmpty()
+	blank.SetName("background")
+
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Equal(t, "GET /users/{id}", routed.Name())
+	assert.Equal(t, "POST", unrouted.Name())
+	assert.Equal(t, "background", blank.Name())
+	_, exists := blank.Attributes().Get("operation.name")
+	assert.False(t, exists)
+}
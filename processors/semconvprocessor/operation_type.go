@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Messaging attributes read when inferring the operation type
+const (
+	messagingSystemAttribute          = "messaging.system"
+	messagingOperationTypeAttribute   = "messaging.operation.type"
+	messagingOperationLegacyAttribute = "messaging.operation"
+)
+
+// messagingOperationType is the operation type inferred for messaging spans
+const messagingOperationType = "messaging"
+
+// messagingOperationTypes maps current and legacy messaging operation values to
+// their messaging.operation.type value
+var messagingOperationTypes = map[string]string{
+	"create":  "create",
+	"send":    "send",
+	"publish": "send",
+	"receive": "receive",
+	"consume": "receive",
+	"process": "process",
+	"deliver": "process",
+	"settle":  "settle",
+}
+
+// inferOperationType derives the operation type from the span's attributes, returning
+// "" when nothing can be inferred. For messaging spans the specific operation is
+// written to messaging.operation.type in its current form, migrating the legacy
+// messaging.operation (not in audit mode). It also reports whether that attribute
+// was written.
+func (sp *semconvProcessor) inferOperationType(span ptrace.Span) (string, bool) {
+	attrs := span.Attributes()
+
+	operation, hasOperation := messagingOperation(attrs)
+	if _, hasSystem := attrs.Get(messagingSystemAttribute); !hasSystem && !hasOperation {
+		return "", false
+	}
+
+	if normalized, known := messagingOperationTypes[strings.ToLower(operation)]; known && sp.config.SpanProcessing.Mode != ModeAudit {
+		if current, exists := attrs.Get(messagingOperationTypeAttribute); !exists || current.AsString() != normalized {
+			attrs.PutStr(messagingOperationTypeAttribute, normalized)
+			return messagingOperationType, true
+		}
+	}
+	return messagingOperationType, false
+}
+
+// messagingOperation returns the messaging operation, preferring the current attribute
+func messagingOperation(attrs pcommon.Map) (string, bool) {
+	for _, key := range []string{messagingOperationTypeAttribute, messagingOperationLegacyAttribute} {
+		if value, exists := attrs.Get(key); exists && value.AsString() != "" {
+			return value.AsString(), true
+		}
+	}
+	return "", false
+}
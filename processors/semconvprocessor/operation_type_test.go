@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestProcessTraces_InferOperationType(t *testing.T) {
+	tests := []struct {
+		name              string
+		attributes        map[string]string
+		ruleType          string
+		expectedType      string
+		expectedOperation string
+	}{
+		{
+			name:              "publish becomes send",
+			attributes:        map[string]string{"messaging.system": "kafka", "messaging.operation.type": "publish"},
+			expectedType:      "messaging",
+			expectedOperation: "send",
+		},
+		{
+			name:              "legacy consume becomes receive",
+			attributes:        map[string]string{"messaging.system": "jms", "messaging.operation": "consume"},
+			expectedType:      "messaging",
+			expectedOperation: "receive",
+		},
+		{
+			name:              "current operation is kept",
+			attributes:        map[string]string{"messaging.operation.type": "process"},
+			expectedType:      "messaging",
+			expectedOperation: "process",
+		},
+		{
+			name:         "messaging system without operation",
+			attributes:   map[string]string{"messaging.system": "rabbitmq"},
+			expectedType: "messaging",
+		},
+		{
+			name:              "rule type takes precedence",
+			attributes:        map[string]string{"messaging.system": "kafka", "messaging.operation.type": "publish"},
+			ruleType:          `"queue"`,
+			expectedType:      "queue",
+			expectedOperation: "publish",
+		},
+		{
+			name:       "non-messaging span",
+			attributes: map[string]string{"http.request.method": "GET"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:            true,
+					Mode:               ModeEnrich,
+					InferOperationType: true,
+					Rules: []OTTLRule{
+						{
+							ID:            "any",
+							Priority:      100,
+							Condition:     "true",
+							OperationName: `"operation"`,
+							OperationType: tt.ruleType,
+						},
+					},
+				},
+			}
+			require.NoError(t, cfg.Validate())
+
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("span")
+			for key, value := range tt.attributes {
+				span.Attributes().PutStr(key, value)
+			}
+
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			attrs := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+
+			operationType, exists := attrs.Get("operation.type")
+			if tt.expectedType == "" {
+				assert.False(t, exists)
+			} else {
+				require.True(t, exists)
+				assert.Equal(t, tt.expectedType, operationType.Str())
+			}
+
+			operation, exists := attrs.Get("messaging.operation.type")
+			if tt.expectedOperation == "" {
+				assert.False(t, exists)
+			} else {
+				require.True(t, exists)
+				assert.Equal(t, tt.expectedOperation, operation.Str())
+			}
+		})
+	}
+}
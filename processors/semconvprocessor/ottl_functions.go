@@ -5,53 +5,121 @@ package semconvprocessor
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash/fnv"
+	"net/netip"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
-// ottlFunctions returns all available OTTL functions including custom ones
-func ottlFunctions[K any]() map[string]ottl.Factory[K] {
+// ottlFunctions returns all available OTTL functions including custom ones.
+// Path functions normalize with paths; RegexReplace skips inputs rejected by regexes;
+// ClassifyService uses the service_classification rules of services.
+func ottlFunctions[K any](paths *pathNormalizer, regexes *regexGuard, services *serviceClassifier) map[string]ottl.Factory[K] {
 	// Start with standard OTTL functions
 	funcs := ottlfuncs.StandardFuncs[K]()
 	
 	// Add custom functions
-	funcs["NormalizePath"] = normalizePathFactory[K]()
+	funcs["NormalizePath"] = normalizePathFactory[K](paths)
+	funcs["NormalizePathExcept"] = normalizePathExceptFactory[K](paths)
 	funcs["ParseSQL"] = parseSQLFactory[K]()
+	funcs["ParseCQL"] = parseCQLFactory[K]()
+	funcs["ParseMongoCommand"] = parseMongoCommandFactory[K]()
 	funcs["RemoveQueryParams"] = removeQueryParamsFactory[K]()
+	funcs["NormalizeQuery"] = normalizeQueryFactory[K]()
 	funcs["FirstNonNil"] = firstNonNilFactory[K]()
+	funcs["Bucket"] = bucketFactory[K]()
+	funcs["ExtractHost"] = extractHostFactory[K]()
+	funcs["ExtractPort"] = extractPortFactory[K]()
+	funcs["NormalizeHTTPMethod"] = normalizeHTTPMethodFactory[K]()
+	funcs["SplitString"] = splitStringFactory[K]()
+	funcs["StripControlChars"] = stripControlCharsFactory[K]()
+	funcs["CollapseWhitespace"] = collapseWhitespaceFactory[K]()
+	funcs["BuildHTTPTarget"] = buildHTTPTargetFactory[K](paths)
+	funcs["HashString"] = hashStringFactory[K]()
+	funcs["ParentAttribute"] = parentAttributeFactory[K]()
+	funcs["RegexReplace"] = regexReplaceFactory[K](regexes)
+	funcs["HashBucket"] = hashBucketFactory[K]()
+	funcs["MapLookup"] = mapLookupFactory[K]()
+	funcs["NormalizeIPs"] = normalizeIPsFactory[K]()
+	funcs["AWSSpanName"] = awsSpanNameFactory[K]()
+	funcs["ClassifyService"] = classifyServiceFactory[K](services)
 	
 	return funcs
 }
 
 
 // normalizePathFactory creates a NormalizePath function
-func normalizePathFactory[K any]() ottl.Factory[K] {
-	return ottl.NewFactory("NormalizePath", &normalizePathArguments[K]{}, createNormalizePathFunction[K])
+func normalizePathFactory[K any](paths *pathNormalizer) ottl.Factory[K] {
+	return ottl.NewFactory("NormalizePath", &normalizePathArguments[K]{}, createNormalizePathFunction[K](paths))
 }
 
 type normalizePathArguments[K any] struct {
 	Path ottl.StringGetter[K]
 }
 
-func createNormalizePathFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
-	args, ok := oArgs.(*normalizePathArguments[K])
-	if !ok {
-		return nil, fmt.Errorf("NormalizePathFactory args must be of type *normalizePathArguments")
+func createNormalizePathFunction[K any](paths *pathNormalizer) ottl.CreateFunctionFunc[K] {
+	return func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*normalizePathArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("NormalizePathFactory args must be of type *normalizePathArguments")
+		}
+
+		return normalizePath(args.Path, paths), nil
 	}
+}
+
+func normalizePath[K any](path ottl.StringGetter[K], paths *pathNormalizer) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		pathStr, err := path.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		return paths.normalize(pathStr), nil
+	})
+}
 
-	return normalizePath(args.Path), nil
+// normalizePathExceptFactory creates a NormalizePathExcept function
+func normalizePathExceptFactory[K any](paths *pathNormalizer) ottl.Factory[K] {
+	return ottl.NewFactory("NormalizePathExcept", &normalizePathExceptArguments[K]{}, createNormalizePathExceptFunction[K](paths))
 }
 
-func normalizePath[K any](path ottl.StringGetter[K]) ottl.ExprFunc[K] {
-	// Compile regex patterns once
-	uuidRe := regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
-	numericRe := regexp.MustCompile(`/\d+(/|$)`)
-	hexRe := regexp.MustCompile(`/[0-9a-fA-F]{16,}(/|$)`)
-	
+type normalizePathExceptArguments[K any] struct {
+	Path     ottl.StringGetter[K]
+	Preserve []int64
+}
+
+func createNormalizePathExceptFunction[K any](paths *pathNormalizer) ottl.CreateFunctionFunc[K] {
+	return func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*normalizePathExceptArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("NormalizePathExceptFactory args must be of type *normalizePathExceptArguments")
+		}
+
+		preserve := make(map[int]bool, len(args.Preserve))
+		for _, index := range args.Preserve {
+			if index < 0 {
+				return nil, fmt.Errorf("NormalizePathExcept segment indices must not be negative, got %d", index)
+			}
+			preserve[int(index)] = true
+		}
+
+		return normalizePathExcept(args.Path, preserve, paths), nil
+	}
+}
+
+func normalizePathExcept[K any](path ottl.StringGetter[K], preserve map[int]bool, paths *pathNormalizer) ottl.ExprFunc[K] {
 	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
 		pathStr, err := path.Get(ctx, tCtx)
 		if err != nil {
@@ -62,17 +130,22 @@ func normalizePath[K any](path ottl.StringGetter[K]) ottl.ExprFunc[K] {
 		if idx := strings.Index(pathStr, "?"); idx != -1 {
 			pathStr = pathStr[:idx]
 		}
+		pathStr = paths.clean(pathStr)
 		
-		// Replace UUIDs with {id}
-		pathStr = uuidRe.ReplaceAllString(pathStr, "{id}")
-		
-		// Replace hex strings (like MongoDB ObjectIds) with {id}
-		pathStr = hexRe.ReplaceAllString(pathStr, "/{id}$1")
-		
-		// Replace numeric IDs with {id}
-		pathStr = numericRe.ReplaceAllString(pathStr, "/{id}$1")
+		// Segments are counted from 0, skipping the empty segment before a leading slash
+		segments := strings.Split(pathStr, "/")
+		index := 0
+		for i, segment := range segments {
+			if segment == "" {
+				continue
+			}
+			if !preserve[index] {
+				segments[i] = paths.normalizeSegment(segment)
+			}
+			index++
+		}
 		
-		return pathStr, nil
+		return paths.truncate(strings.Join(segments, "/")), nil
 	})
 }
 
@@ -156,6 +229,143 @@ func cleanTableName(table string) string {
 	return table
 }
 
+// parseCQLFactory creates a ParseCQL function
+func parseCQLFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseCQL", &parseCQLArguments[K]{}, createParseCQLFunction[K])
+}
+
+type parseCQLArguments[K any] struct {
+	Statement ottl.StringGetter[K]
+}
+
+func createParseCQLFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*parseCQLArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("ParseCQLFactory args must be of type *parseCQLArguments")
+	}
+
+	return parseCQL(args.Statement), nil
+}
+
+func parseCQL[K any](statement ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	// Compile regex patterns for CQL parsing; table names stop at whitespace,
+	// a column list or the end of the statement
+	batchRe := regexp.MustCompile(`(?i)^\s*BEGIN\s+(?:UNLOGGED\s+|COUNTER\s+)?BATCH\b`)
+	selectRe := regexp.MustCompile(`(?is)^\s*SELECT\s+.*?\s+FROM\s+([^\s(;]+)`)
+	insertRe := regexp.MustCompile(`(?i)^\s*INSERT\s+INTO\s+([^\s(;]+)`)
+	updateRe := regexp.MustCompile(`(?i)^\s*UPDATE\s+([^\s(;]+)`)
+	deleteRe := regexp.MustCompile(`(?is)^\s*DELETE\s+(?:.*?\s+)?FROM\s+([^\s(;]+)`)
+	
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		stmtStr, err := statement.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		// Normalize whitespace
+		stmtStr = strings.TrimSpace(stmtStr)
+		
+		// A batch groups several statements, so it is named by the batch alone
+		if batchRe.MatchString(stmtStr) {
+			return "BATCH", nil
+		}
+		
+		// Extract operation and keyspace.table
+		if matches := selectRe.FindStringSubmatch(stmtStr); len(matches) > 1 {
+			return fmt.Sprintf("SELECT %s", cleanCQLTableName(matches[1])), nil
+		}
+		
+		if matches := insertRe.FindStringSubmatch(stmtStr); len(matches) > 1 {
+			return fmt.Sprintf("INSERT %s", cleanCQLTableName(matches[1])), nil
+		}
+		
+		if matches := updateRe.FindStringSubmatch(stmtStr); len(matches) > 1 {
+			return fmt.Sprintf("UPDATE %s", cleanCQLTableName(matches[1])), nil
+		}
+		
+		if matches := deleteRe.FindStringSubmatch(stmtStr); len(matches) > 1 {
+			return fmt.Sprintf("DELETE %s", cleanCQLTableName(matches[1])), nil
+		}
+		
+		// If we can't parse it, return the first word as operation
+		parts := strings.Fields(stmtStr)
+		if len(parts) > 0 {
+			return strings.ToUpper(parts[0]), nil
+		}
+		
+		return "UNKNOWN", nil
+	})
+}
+
+// cleanCQLTableName removes quotes from a CQL table name but keeps the keyspace
+// prefix, which the semantic conventions recommend for Cassandra
+func cleanCQLTableName(table string) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = cleanTableName(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// mongoCollectionCommands are MongoDB commands whose leading value names the collection
+var mongoCollectionCommands = map[string]bool{
+	"find":          true,
+	"insert":        true,
+	"update":        true,
+	"delete":        true,
+	"aggregate":     true,
+	"count":         true,
+	"distinct":      true,
+	"findAndModify": true,
+	"createIndexes": true,
+	"drop":          true,
+}
+
+// parseMongoCommandFactory creates a ParseMongoCommand function
+func parseMongoCommandFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseMongoCommand", &parseMongoCommandArguments[K]{}, createParseMongoCommandFunction[K])
+}
+
+type parseMongoCommandArguments[K any] struct {
+	Command ottl.StringGetter[K]
+}
+
+func createParseMongoCommandFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*parseMongoCommandArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("ParseMongoCommandFactory args must be of type *parseMongoCommandArguments")
+	}
+
+	return parseMongoCommand(args.Command), nil
+}
+
+func parseMongoCommand[K any](command ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	// The command verb is the first key of the document; keys may be unquoted in
+	// shell-style documents and the value is the collection for collection commands
+	leadingKeyRe := regexp.MustCompile(`^\s*\{\s*["']?(\$?\w+)["']?\s*:\s*(?:"([^"]*)"|'([^']*)')?`)
+	
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		cmdStr, err := command.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		matches := leadingKeyRe.FindStringSubmatch(cmdStr)
+		if len(matches) == 0 {
+			return "UNKNOWN", nil
+		}
+		
+		verb := matches[1]
+		collection := matches[2] + matches[3]
+		if mongoCollectionCommands[verb] && collection != "" {
+			return fmt.Sprintf("%s %s", verb, collection), nil
+		}
+		
+		// Database-level commands (or aggregate: 1) have no collection
+		return verb, nil
+	})
+}
+
 // removeQueryParamsFactory creates a RemoveQueryParams function
 func removeQueryParamsFactory[K any]() ottl.Factory[K] {
 	return ottl.NewFactory("RemoveQueryParams", &removeQueryParamsArguments[K]{}, createRemoveQueryParamsFunction[K])
@@ -189,6 +399,61 @@ func removeQueryParams[K any](path ottl.StringGetter[K]) ottl.ExprFunc[K] {
 	})
 }
 
+// normalizeQueryFactory creates a NormalizeQuery function
+func normalizeQueryFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("NormalizeQuery", &normalizeQueryArguments[K]{}, createNormalizeQueryFunction[K])
+}
+
+type normalizeQueryArguments[K any] struct {
+	Path ottl.StringGetter[K]
+}
+
+func createNormalizeQueryFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*normalizeQueryArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("NormalizeQueryFactory args must be of type *normalizeQueryArguments")
+	}
+
+	return normalizeQuery(args.Path), nil
+}
+
+// normalizeQuery replaces the query with its sorted, distinct parameter names, so the
+// parameters that were present stay visible without their high-cardinality values
+func normalizeQuery[K any](path ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		pathStr, err := path.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		// The fragment is never sent to the server
+		if idx := strings.Index(pathStr, "#"); idx != -1 {
+			pathStr = pathStr[:idx]
+		}
+		idx := strings.Index(pathStr, "?")
+		if idx == -1 {
+			return pathStr, nil
+		}
+		
+		seen := make(map[string]bool)
+		var keys []string
+		for _, param := range strings.FieldsFunc(pathStr[idx+1:], func(r rune) bool { return r == '&' || r == ';' }) {
+			key, _, _ := strings.Cut(param, "=")
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			return pathStr[:idx], nil
+		}
+		sort.Strings(keys)
+		
+		return pathStr[:idx] + "?" + strings.Join(keys, "&"), nil
+	})
+}
+
 // firstNonNilFactory creates a FirstNonNil function
 func firstNonNilFactory[K any]() ottl.Factory[K] {
 	return ottl.NewFactory("FirstNonNil", &firstNonNilArguments[K]{}, createFirstNonNilFunction[K])
@@ -222,4 +487,754 @@ func firstNonNil[K any](values []ottl.Getter[K]) ottl.ExprFunc[K] {
 		// If all values are nil or errored, return nil
 		return nil, nil
 	})
-}
\ No newline at end of file
+}
+
+// bucketFactory creates a Bucket function
+func bucketFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Bucket", &bucketArguments[K]{}, createBucketFunction[K])
+}
+
+type bucketArguments[K any] struct {
+	Value      ottl.FloatLikeGetter[K]
+	Boundaries []float64
+}
+
+func createBucketFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*bucketArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("BucketFactory args must be of type *bucketArguments")
+	}
+
+	if len(args.Boundaries) == 0 {
+		return nil, fmt.Errorf("Bucket requires at least one boundary")
+	}
+	for i := 1; i < len(args.Boundaries); i++ {
+		if args.Boundaries[i] <= args.Boundaries[i-1] {
+			return nil, fmt.Errorf("Bucket boundaries must be sorted in ascending order, got %v", args.Boundaries)
+		}
+	}
+
+	return bucket(args.Value, args.Boundaries), nil
+}
+
+func bucket[K any](value ottl.FloatLikeGetter[K], boundaries []float64) ottl.ExprFunc[K] {
+	// Pre-compute labels so evaluation does not allocate
+	labels := make([]string, len(boundaries)+1)
+	labels[0] = "<" + formatBoundary(boundaries[0])
+	for i := 1; i < len(boundaries); i++ {
+		labels[i] = formatBoundary(boundaries[i-1]) + "-" + formatBoundary(boundaries[i])
+	}
+	labels[len(boundaries)] = formatBoundary(boundaries[len(boundaries)-1]) + "+"
+
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		val, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+
+		// Find the first boundary greater than the value; lower bounds are inclusive
+		for i, boundary := range boundaries {
+			if *val < boundary {
+				return labels[i], nil
+			}
+		}
+
+		return labels[len(boundaries)], nil
+	})
+}
+
+// formatBoundary renders a bucket boundary without trailing zeros
+func formatBoundary(boundary float64) string {
+	return strconv.FormatFloat(boundary, 'f', -1, 64)
+}
+
+// extractHostFactory creates an ExtractHost function
+func extractHostFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ExtractHost", &extractHostArguments[K]{}, createExtractHostFunction[K])
+}
+
+type extractHostArguments[K any] struct {
+	Authority ottl.StringGetter[K]
+}
+
+func createExtractHostFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*extractHostArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("ExtractHostFactory args must be of type *extractHostArguments")
+	}
+
+	return extractHost(args.Authority), nil
+}
+
+func extractHost[K any](authority ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		authorityStr, err := authority.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		host, _ := splitAuthority(authorityStr)
+		return host, nil
+	})
+}
+
+// extractPortFactory creates an ExtractPort function
+func extractPortFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ExtractPort", &extractPortArguments[K]{}, createExtractPortFunction[K])
+}
+
+type extractPortArguments[K any] struct {
+	Authority ottl.StringGetter[K]
+}
+
+func createExtractPortFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*extractPortArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("ExtractPortFactory args must be of type *extractPortArguments")
+	}
+
+	return extractPort(args.Authority), nil
+}
+
+func extractPort[K any](authority ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		authorityStr, err := authority.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		_, portStr := splitAuthority(authorityStr)
+		port, err := strconv.ParseInt(portStr, 10, 64)
+		if err != nil {
+			// No port or not a number
+			return nil, nil
+		}
+		return port, nil
+	})
+}
+
+// splitAuthority splits a host:port authority on its last colon. Bracketed IPv6
+// addresses like [::1]:8080 are returned without brackets, and an unbracketed
+// address with several colons is treated as a bare IPv6 host.
+func splitAuthority(authority string) (host, port string) {
+	if strings.HasPrefix(authority, "[") {
+		end := strings.Index(authority, "]")
+		if end == -1 {
+			return authority, ""
+		}
+		host = authority[1:end]
+		if rest := authority[end+1:]; strings.HasPrefix(rest, ":") {
+			port = rest[1:]
+		}
+		return host, port
+	}
+	
+	if strings.Count(authority, ":") != 1 {
+		return authority, ""
+	}
+	
+	idx := strings.LastIndex(authority, ":")
+	return authority[:idx], authority[idx+1:]
+}
+
+// knownHTTPMethods are the request methods recognised by the HTTP semantic conventions
+var knownHTTPMethods = map[string]bool{
+	"CONNECT": true,
+	"DELETE":  true,
+	"GET":     true,
+	"HEAD":    true,
+	"OPTIONS": true,
+	"PATCH":   true,
+	"POST":    true,
+	"PUT":     true,
+	"TRACE":   true,
+}
+
+// otherHTTPMethod replaces methods that are not in knownHTTPMethods
+const otherHTTPMethod = "_OTHER"
+
+// normalizeHTTPMethodFactory creates a NormalizeHTTPMethod function
+func normalizeHTTPMethodFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("NormalizeHTTPMethod", &normalizeHTTPMethodArguments[K]{}, createNormalizeHTTPMethodFunction[K])
+}
+
+type normalizeHTTPMethodArguments[K any] struct {
+	Method ottl.StringGetter[K]
+}
+
+func createNormalizeHTTPMethodFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*normalizeHTTPMethodArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("NormalizeHTTPMethodFactory args must be of type *normalizeHTTPMethodArguments")
+	}
+
+	return normalizeHTTPMethod(args.Method), nil
+}
+
+func normalizeHTTPMethod[K any](method ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		methodStr, err := method.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		return normalizeHTTPMethodString(methodStr), nil
+	})
+}
+
+// normalizeHTTPMethodString uppercases a known HTTP method and maps anything else to _OTHER
+func normalizeHTTPMethodString(method string) string {
+	upper := strings.ToUpper(method)
+	if knownHTTPMethods[upper] {
+		return upper
+	}
+	return otherHTTPMethod
+}
+
+// splitStringFactory creates a SplitString function
+func splitStringFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("SplitString", &splitStringArguments[K]{}, createSplitStringFunction[K])
+}
+
+type splitStringArguments[K any] struct {
+	Value     ottl.StringGetter[K]
+	Separator string
+	Trim      ottl.Optional[bool]
+}
+
+func createSplitStringFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*splitStringArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("SplitStringFactory args must be of type *splitStringArguments")
+	}
+
+	if args.Separator == "" {
+		return nil, fmt.Errorf("SplitString separator must not be empty")
+	}
+
+	trim := false
+	if !args.Trim.IsEmpty() {
+		trim = args.Trim.Get()
+	}
+
+	return splitString(args.Value, args.Separator, trim), nil
+}
+
+func splitString[K any](value ottl.StringGetter[K], separator string, trim bool) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		valueStr, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		// Return a pcommon.Slice so the result can be written as an array attribute
+		parts := pcommon.NewSlice()
+		if valueStr == "" {
+			return parts, nil
+		}
+		for _, part := range strings.Split(valueStr, separator) {
+			if trim {
+				part = strings.TrimSpace(part)
+			}
+			parts.AppendEmpty().SetStr(part)
+		}
+		return parts, nil
+	})
+}
+
+// ansiEscapePattern matches ANSI CSI sequences (colors, cursor movement),
+// OSC sequences (terminal titles, hyperlinks) and two-character escapes
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// stripControlCharsFactory creates a StripControlChars function
+func stripControlCharsFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("StripControlChars", &stripControlCharsArguments[K]{}, createStripControlCharsFunction[K])
+}
+
+type stripControlCharsArguments[K any] struct {
+	Value ottl.StringGetter[K]
+}
+
+func createStripControlCharsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*stripControlCharsArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("StripControlCharsFactory args must be of type *stripControlCharsArguments")
+	}
+
+	return stripControlChars(args.Value), nil
+}
+
+func stripControlChars[K any](value ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		valueStr, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		// Remove whole escape sequences first so their printable tails do not survive
+		valueStr = ansiEscapePattern.ReplaceAllString(valueStr, "")
+		return strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, valueStr), nil
+	})
+}
+
+// collapseWhitespaceFactory creates a CollapseWhitespace function
+func collapseWhitespaceFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("CollapseWhitespace", &collapseWhitespaceArguments[K]{}, createCollapseWhitespaceFunction[K])
+}
+
+type collapseWhitespaceArguments[K any] struct {
+	Value ottl.StringGetter[K]
+}
+
+func createCollapseWhitespaceFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*collapseWhitespaceArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("CollapseWhitespaceFactory args must be of type *collapseWhitespaceArguments")
+	}
+
+	return collapseWhitespace(args.Value), nil
+}
+
+func collapseWhitespace[K any](value ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		valueStr, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		// Fields splits on runs of Unicode whitespace and drops leading and trailing ones
+		return strings.Join(strings.Fields(valueStr), " "), nil
+	})
+}
+
+// buildHTTPTargetFactory creates a BuildHTTPTarget function
+func buildHTTPTargetFactory[K any](paths *pathNormalizer) ottl.Factory[K] {
+	return ottl.NewFactory("BuildHTTPTarget", &buildHTTPTargetArguments[K]{}, createBuildHTTPTargetFunction[K](paths))
+}
+
+type buildHTTPTargetArguments[K any] struct {
+	Method ottl.StringLikeGetter[K]
+	Scheme ottl.StringLikeGetter[K]
+	Host   ottl.StringLikeGetter[K]
+	Path   ottl.StringLikeGetter[K]
+}
+
+func createBuildHTTPTargetFunction[K any](paths *pathNormalizer) ottl.CreateFunctionFunc[K] {
+	return func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*buildHTTPTargetArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("BuildHTTPTargetFactory args must be of type *buildHTTPTargetArguments")
+		}
+
+		return buildHTTPTarget(args.Method, args.Scheme, args.Host, args.Path, paths), nil
+	}
+}
+
+func buildHTTPTarget[K any](method, scheme, host, path ottl.StringLikeGetter[K], paths *pathNormalizer) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		// Missing values are allowed, so nil results are read as empty strings
+		var values [4]string
+		for i, getter := range []ottl.StringLikeGetter[K]{method, scheme, host, path} {
+			value, err := getter.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				values[i] = *value
+			}
+		}
+		methodStr, schemeStr, hostStr, pathStr := values[0], values[1], values[2], values[3]
+		
+		if hostStr == "" && pathStr == "" {
+			return nil, nil
+		}
+		
+		target := paths.normalize(pathStr)
+		if target == "" {
+			target = "/"
+		}
+		
+		// Without a host the scheme is meaningless, so only the path is used
+		if hostStr != "" {
+			if schemeStr != "" {
+				target = schemeStr + "://" + hostStr + target
+			} else {
+				target = hostStr + target
+			}
+		}
+		
+		if methodStr == "" {
+			return target, nil
+		}
+		return methodStr + " " + target, nil
+	})
+}
+
+// hashStringFactory creates a HashString function
+func hashStringFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("HashString", &hashStringArguments[K]{}, createHashStringFunction[K])
+}
+
+type hashStringArguments[K any] struct {
+	Value ottl.Getter[K]
+}
+
+func createHashStringFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*hashStringArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("HashStringFactory args must be of type *hashStringArguments")
+	}
+
+	return hashString(args.Value), nil
+}
+
+// hashString returns the hex-encoded SHA-256 digest of a string or bytes value.
+// A string is hashed as its UTF-8 bytes, so "abc" and the bytes of "abc" hash alike.
+func hashString[K any](value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		val, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		var data []byte
+		switch v := val.(type) {
+		case nil:
+			return nil, nil
+		case string:
+			data = []byte(v)
+		case []byte:
+			data = v
+		case pcommon.ByteSlice:
+			data = v.AsRaw()
+		case pcommon.Value:
+			switch v.Type() {
+			case pcommon.ValueTypeStr:
+				data = []byte(v.Str())
+			case pcommon.ValueTypeBytes:
+				data = v.Bytes().AsRaw()
+			case pcommon.ValueTypeEmpty:
+				return nil, nil
+			default:
+				return nil, fmt.Errorf("HashString expects a string or bytes value, got %s", v.Type())
+			}
+		default:
+			return nil, fmt.Errorf("HashString expects a string or bytes value, got %T", val)
+		}
+		
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	})
+}
+
+// parentAttributeFactory creates a ParentAttribute function
+func parentAttributeFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParentAttribute", &parentAttributeArguments[K]{}, createParentAttributeFunction[K])
+}
+
+type parentAttributeArguments[K any] struct {
+	Key string
+}
+
+func createParentAttributeFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*parentAttributeArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("ParentAttributeFactory args must be of type *parentAttributeArguments")
+	}
+
+	return parentAttribute[K](args.Key), nil
+}
+
+// parentAttribute reads an attribute of the current span's parent. The parent is looked
+// up in the batch index built when index_parents is enabled; without an index, for root
+// spans and for parents outside the batch it returns nil.
+func parentAttribute[K any](key string) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		spanCtx, ok := any(tCtx).(ottlspan.TransformContext)
+		if !ok {
+			return nil, nil
+		}
+		
+		parent, ok := parentSpan(ctx, spanCtx.GetSpan())
+		if !ok {
+			return nil, nil
+		}
+		value, exists := parent.Attributes().Get(key)
+		if !exists {
+			return nil, nil
+		}
+		return value.AsRaw(), nil
+	})
+}
+
+// regexReplaceFactory creates a RegexReplace function
+func regexReplaceFactory[K any](regexes *regexGuard) ottl.Factory[K] {
+	return ottl.NewFactory("RegexReplace", &regexReplaceArguments[K]{}, createRegexReplaceFunction[K](regexes))
+}
+
+type regexReplaceArguments[K any] struct {
+	Value       ottl.StringGetter[K]
+	Pattern     string
+	Replacement string
+}
+
+func createRegexReplaceFunction[K any](regexes *regexGuard) ottl.CreateFunctionFunc[K] {
+	return func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*regexReplaceArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("RegexReplaceFactory args must be of type *regexReplaceArguments")
+		}
+
+		// The pattern is a literal, so it is compiled once when the rule is compiled
+		pattern, err := regexp.Compile(args.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("RegexReplace pattern %q is invalid: %w", args.Pattern, err)
+		}
+
+		return regexReplace(args.Value, pattern, args.Replacement, regexes), nil
+	}
+}
+
+func regexReplace[K any](value ottl.StringGetter[K], pattern *regexp.Regexp, replacement string, regexes *regexGuard) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		valueStr, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		// Oversized inputs are returned unchanged
+		if !regexes.allow(ctx, valueStr) {
+			return valueStr, nil
+		}
+		
+		// ReplaceAllString expands $1 and ${name} references to capture groups
+		return pattern.ReplaceAllString(valueStr, replacement), nil
+	})
+}
+
+// hashBucketFactory creates a HashBucket function
+func hashBucketFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("HashBucket", &hashBucketArguments[K]{}, createHashBucketFunction[K])
+}
+
+type hashBucketArguments[K any] struct {
+	Value ottl.StringLikeGetter[K]
+	N     int64
+}
+
+func createHashBucketFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*hashBucketArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("HashBucketFactory args must be of type *hashBucketArguments")
+	}
+
+	if args.N <= 0 {
+		return nil, fmt.Errorf("HashBucket bucket count must be greater than 0, got %d", args.N)
+	}
+
+	return hashBucket(args.Value, uint64(args.N)), nil
+}
+
+// hashBucket assigns a value to one of n buckets, numbered 0 to n-1, using FNV-1a,
+// so the same value lands in the same bucket on every collector
+func hashBucket[K any](value ottl.StringLikeGetter[K], n uint64) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		val, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return nil, nil
+		}
+		
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(*val))
+		return int64(hash.Sum64() % n), nil
+	})
+}
+
+// mapLookupFactory creates a MapLookup function
+func mapLookupFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("MapLookup", &mapLookupArguments[K]{}, createMapLookupFunction[K])
+}
+
+type mapLookupArguments[K any] struct {
+	Key     ottl.StringLikeGetter[K]
+	Mapping ottl.PMapGetter[K]
+	Default ottl.Optional[ottl.Getter[K]]
+}
+
+func createMapLookupFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*mapLookupArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("MapLookupFactory args must be of type *mapLookupArguments")
+	}
+
+	return mapLookup(args.Key, args.Mapping, args.Default), nil
+}
+
+// mapLookup returns the mapping's value for the key in its native type, or the
+// default (nil when none is given) when the key is missing or nil
+func mapLookup[K any](key ottl.StringLikeGetter[K], mapping ottl.PMapGetter[K], defaultValue ottl.Optional[ottl.Getter[K]]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		keyStr, err := key.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		if keyStr != nil {
+			m, err := mapping.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			if value, exists := m.Get(*keyStr); exists {
+				return value.AsRaw(), nil
+			}
+		}
+		
+		if defaultValue.IsEmpty() {
+			return nil, nil
+		}
+		return defaultValue.Get().Get(ctx, tCtx)
+	})
+}
+
+// ipCandidatePattern matches runs of characters that may form an IP address. Each
+// run is parsed in full, so version numbers (1.2.3) or longer dotted sequences
+// (1.2.3.4.5) are left alone.
+var ipCandidatePattern = regexp.MustCompile(`[0-9A-Za-z:.]+`)
+
+// ipPlaceholder replaces IP addresses in NormalizeIPs
+const ipPlaceholder = "{ip}"
+
+// normalizeIPsFactory creates a NormalizeIPs function
+func normalizeIPsFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("NormalizeIPs", &normalizeIPsArguments[K]{}, createNormalizeIPsFunction[K])
+}
+
+type normalizeIPsArguments[K any] struct {
+	Value ottl.StringGetter[K]
+}
+
+func createNormalizeIPsFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*normalizeIPsArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("NormalizeIPsFactory args must be of type *normalizeIPsArguments")
+	}
+
+	return normalizeIPs(args.Value), nil
+}
+
+func normalizeIPs[K any](value ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		valueStr, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		return ipCandidatePattern.ReplaceAllStringFunc(valueStr, replaceIP), nil
+	})
+}
+
+// replaceIP returns the placeholder for an IPv4 or IPv6 literal, keeping the port
+// of an IPv4 address (10.0.0.5:8080 → {ip}:8080). Anything else is returned unchanged.
+func replaceIP(candidate string) string {
+	if !strings.ContainsAny(candidate, ".:") {
+		return candidate
+	}
+	if _, err := netip.ParseAddr(candidate); err == nil {
+		return ipPlaceholder
+	}
+	if i := strings.LastIndexByte(candidate, ':'); i > 0 {
+		if addr, err := netip.ParseAddr(candidate[:i]); err == nil && addr.Is4() {
+			return ipPlaceholder + candidate[i:]
+		}
+	}
+	return candidate
+}
+
+// awsSpanNameFactory creates an AWSSpanName function
+func awsSpanNameFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("AWSSpanName", &awsSpanNameArguments[K]{}, createAWSSpanNameFunction[K])
+}
+
+type awsSpanNameArguments[K any] struct {
+	Service ottl.StringLikeGetter[K]
+	Method  ottl.StringLikeGetter[K]
+}
+
+func createAWSSpanNameFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*awsSpanNameArguments[K])
+	if !ok {
+		return nil, fmt.Errorf("AWSSpanNameFactory args must be of type *awsSpanNameArguments")
+	}
+
+	return awsSpanName(args.Service, args.Method), nil
+}
+
+func awsSpanName[K any](service, method ottl.StringLikeGetter[K]) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		serviceVal, err := service.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		methodVal, err := method.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		// Without a service there is nothing AWS-specific to name, so the rule can fall back
+		if serviceVal == nil || strings.TrimSpace(*serviceVal) == "" {
+			return nil, nil
+		}
+		serviceName := strings.TrimSpace(*serviceVal)
+		if methodVal == nil || strings.TrimSpace(*methodVal) == "" {
+			return serviceName, nil
+		}
+		return serviceName + "." + strings.TrimSpace(*methodVal), nil
+	})
+}
+
+// classifyServiceFactory creates a ClassifyService function
+func classifyServiceFactory[K any](services *serviceClassifier) ottl.Factory[K] {
+	return ottl.NewFactory("ClassifyService", &classifyServiceArguments[K]{}, createClassifyServiceFunction[K](services))
+}
+
+type classifyServiceArguments[K any] struct {
+	ServiceName ottl.StringLikeGetter[K]
+}
+
+func createClassifyServiceFunction[K any](services *serviceClassifier) ottl.CreateFunctionFunc[K] {
+	return func(_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+		args, ok := oArgs.(*classifyServiceArguments[K])
+		if !ok {
+			return nil, fmt.Errorf("ClassifyServiceFactory args must be of type *classifyServiceArguments")
+		}
+
+		return classifyService(args.ServiceName, services), nil
+	}
+}
+
+// classifyService returns the label of the first service_classification rule matching
+// the service name, or the default label when none matches or the name is nil
+func classifyService[K any](serviceName ottl.StringLikeGetter[K], services *serviceClassifier) ottl.ExprFunc[K] {
+	return ottl.ExprFunc[K](func(ctx context.Context, tCtx K) (any, error) {
+		nameStr, err := serviceName.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		
+		if nameStr == nil {
+			return services.fallback, nil
+		}
+		return services.classify(ctx, *nameStr), nil
+	})
+}
@@ -5,11 +5,15 @@ package semconvprocessor
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.uber.org/zap"
@@ -172,4 +176,1513 @@ func TestFirstNonNil_MultipleAttributes(t *testing.T) {
 			assert.Equal(t, tt.expectedName, resultSpan.Name())
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestBucket(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+	}{
+		{
+			name:     "first bucket",
+			value:    int64(42),
+			expected: "<100",
+		},
+		{
+			name:     "lower boundary is inclusive",
+			value:    int64(100),
+			expected: "100-500",
+		},
+		{
+			name:     "middle bucket",
+			value:    250.5,
+			expected: "100-500",
+		},
+		{
+			name:     "overflow bucket",
+			value:    int64(1500),
+			expected: "1000+",
+		},
+		{
+			name:     "numeric string",
+			value:    "750",
+			expected: "500-1000",
+		},
+		{
+			name:     "nil value",
+			value:    nil,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &bucketArguments[any]{
+				Value: ottl.StandardFloatLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+				Boundaries: []float64{100, 500, 1000},
+			}
+			exprFunc, err := createBucketFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBucket_InvalidBoundaries(t *testing.T) {
+	tests := []struct {
+		name       string
+		boundaries []float64
+		errMsg     string
+	}{
+		{
+			name:       "no boundaries",
+			boundaries: []float64{},
+			errMsg:     "at least one boundary",
+		},
+		{
+			name:       "descending boundaries",
+			boundaries: []float64{500, 100},
+			errMsg:     "ascending order",
+		},
+		{
+			name:       "duplicate boundaries",
+			boundaries: []float64{100, 100},
+			errMsg:     "ascending order",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &bucketArguments[any]{Boundaries: tt.boundaries}
+			_, err := createBucketFunction[any](ottl.FunctionContext{}, args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestProcessTraces_Bucket(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "payload_size",
+					Priority:      100,
+					Condition:     `attributes["http.request.body.size"] != nil`,
+					OperationName: `Concat(["upload", Bucket(attributes["http.request.body.size"], [1024.0, 1048576.0])], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("upload")
+	span.Attributes().PutInt("http.request.body.size", 4096)
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	val, exists := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("operation.name")
+	require.True(t, exists)
+	assert.Equal(t, "upload 1024-1048576", val.Str())
+}
+
+func TestProcessTraces_BucketUnsortedBoundaries(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "unsorted",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `Bucket(attributes["duration"], [500.0, 100.0])`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	_, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ascending order")
+}
+
+func TestExtractHostAndPort(t *testing.T) {
+	tests := []struct {
+		name         string
+		authority    string
+		expectedHost any
+		expectedPort any
+	}{
+		{
+			name:         "host with port",
+			authority:    "example.com:443",
+			expectedHost: "example.com",
+			expectedPort: int64(443),
+		},
+		{
+			name:         "bare host",
+			authority:    "example.com",
+			expectedHost: "example.com",
+			expectedPort: nil,
+		},
+		{
+			name:         "bracketed IPv6 with port",
+			authority:    "[::1]:8080",
+			expectedHost: "::1",
+			expectedPort: int64(8080),
+		},
+		{
+			name:         "bracketed IPv6 without port",
+			authority:    "[2001:db8::1]",
+			expectedHost: "2001:db8::1",
+			expectedPort: nil,
+		},
+		{
+			name:         "unbracketed IPv6",
+			authority:    "2001:db8::1",
+			expectedHost: "2001:db8::1",
+			expectedPort: nil,
+		},
+		{
+			name:         "non-numeric port",
+			authority:    "example.com:https",
+			expectedHost: "example.com",
+			expectedPort: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getter := ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.authority, nil
+				},
+			}
+
+			hostFunc, err := createExtractHostFunction[any](ottl.FunctionContext{}, &extractHostArguments[any]{Authority: getter})
+			require.NoError(t, err)
+			host, err := hostFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedHost, host)
+
+			portFunc, err := createExtractPortFunction[any](ottl.FunctionContext{}, &extractPortArguments[any]{Authority: getter})
+			require.NoError(t, err)
+			port, err := portFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPort, port)
+		})
+	}
+}
+
+func TestNormalizeHTTPMethod(t *testing.T) {
+	tests := []struct {
+		method   string
+		expected string
+	}{
+		{method: "get", expected: "GET"},
+		{method: "Post", expected: "POST"},
+		{method: "DELETE", expected: "DELETE"},
+		{method: "PROPFIND", expected: "_OTHER"},
+		{method: "", expected: "_OTHER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			args := &normalizeHTTPMethodArguments[any]{
+				Method: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.method, nil
+					},
+				},
+			}
+			exprFunc, err := createNormalizeHTTPMethodFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestProcessTraces_NormalizeHTTPMethod(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		expectedMethod   string
+		expectedOriginal string // empty means the attribute must be absent
+		expectedName     string
+	}{
+		{
+			name:             "lowercase known method",
+			method:           "get",
+			expectedMethod:   "GET",
+			expectedOriginal: "get",
+			expectedName:     "GET /users",
+		},
+		{
+			name:             "unknown method",
+			method:           "PROPFIND",
+			expectedMethod:   "_OTHER",
+			expectedOriginal: "PROPFIND",
+			expectedName:     "_OTHER /users",
+		},
+		{
+			name:           "already valid",
+			method:         "POST",
+			expectedMethod: "POST",
+			expectedName:   "POST /users",
+		},
+	}
+
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:             true,
+			Mode:                ModeEnforce,
+			NormalizeHTTPMethod: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.request.method"] != nil`,
+					OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("request")
+			span.Attributes().PutStr("http.request.method", tt.method)
+			span.Attributes().PutStr("http.route", "/users")
+
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expectedName, resultSpan.Name())
+
+			method, _ := resultSpan.Attributes().Get("http.request.method")
+			assert.Equal(t, tt.expectedMethod, method.Str())
+
+			original, exists := resultSpan.Attributes().Get("http.request.method_original")
+			if tt.expectedOriginal == "" {
+				assert.False(t, exists)
+			} else {
+				require.True(t, exists)
+				assert.Equal(t, tt.expectedOriginal, original.Str())
+			}
+		})
+	}
+}
+
+func TestParseCQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "keyspaced select",
+			input:    "SELECT id, name FROM shop.users WHERE id = ?",
+			expected: "SELECT shop.users",
+		},
+		{
+			name:     "quoted keyspace and table",
+			input:    `SELECT * FROM "Shop"."Users" LIMIT 10`,
+			expected: "SELECT Shop.Users",
+		},
+		{
+			name:     "insert with TTL",
+			input:    "INSERT INTO shop.carts(id, items) VALUES (?, ?) USING TTL 86400",
+			expected: "INSERT shop.carts",
+		},
+		{
+			name:     "update with TTL",
+			input:    "UPDATE shop.sessions USING TTL 3600 SET token = ? WHERE id = ?",
+			expected: "UPDATE shop.sessions",
+		},
+		{
+			name:     "delete columns",
+			input:    "DELETE email FROM shop.users WHERE id = ?",
+			expected: "DELETE shop.users",
+		},
+		{
+			name:     "table without keyspace",
+			input:    "DELETE FROM users WHERE id = ?",
+			expected: "DELETE users",
+		},
+		{
+			name:     "batch",
+			input:    "BEGIN BATCH INSERT INTO shop.orders (id) VALUES (?); UPDATE shop.users SET orders = ? WHERE id = ?; APPLY BATCH",
+			expected: "BATCH",
+		},
+		{
+			name:     "unlogged batch",
+			input:    "begin unlogged batch insert into shop.events (id) values (?); apply batch",
+			expected: "BATCH",
+		},
+		{
+			name:     "schema statement",
+			input:    "TRUNCATE shop.sessions",
+			expected: "TRUNCATE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &parseCQLArguments[any]{
+				Statement: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.input, nil
+					},
+				},
+			}
+			exprFunc, err := createParseCQLFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestParseMongoCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "find with collection",
+			input:    `{"find":"users","filter":{"_id":{"$oid":"65a1"}}}`,
+			expected: "find users",
+		},
+		{
+			name:     "insert with collection",
+			input:    `{"insert": "orders", "documents": [{"total": 42}]}`,
+			expected: "insert orders",
+		},
+		{
+			name:     "shell style document",
+			input:    `{ update: 'carts', updates: [ { q: {}, u: {} } ] }`,
+			expected: "update carts",
+		},
+		{
+			name:     "database level aggregate",
+			input:    `{"aggregate": 1, "pipeline": [{"$currentOp": {}}]}`,
+			expected: "aggregate",
+		},
+		{
+			name:     "command without collection",
+			input:    `{"ping": 1}`,
+			expected: "ping",
+		},
+		{
+			name:     "not a document",
+			input:    `db.users.find()`,
+			expected: "UNKNOWN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &parseMongoCommandArguments[any]{
+				Command: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.input, nil
+					},
+				},
+			}
+			exprFunc, err := createParseMongoCommandFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestSplitString(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		separator string
+		trim      ottl.Optional[bool]
+		expected  []any
+	}{
+		{
+			name:      "multiple parts",
+			value:     "text/html,application/json,*/*",
+			separator: ",",
+			expected:  []any{"text/html", "application/json", "*/*"},
+		},
+		{
+			name:      "whitespace kept by default",
+			value:     "a, b ,c",
+			separator: ",",
+			expected:  []any{"a", " b ", "c"},
+		},
+		{
+			name:      "whitespace trimmed",
+			value:     "a, b ,c",
+			separator: ",",
+			trim:      ottl.NewTestingOptional(true),
+			expected:  []any{"a", "b", "c"},
+		},
+		{
+			name:      "empty input",
+			value:     "",
+			separator: ",",
+			expected:  []any{},
+		},
+		{
+			name:      "separator not present",
+			value:     "gzip",
+			separator: ",",
+			expected:  []any{"gzip"},
+		},
+		{
+			name:      "multi-character separator",
+			value:     "a::b",
+			separator: "::",
+			expected:  []any{"a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &splitStringArguments[any]{
+				Value: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+				Separator: tt.separator,
+				Trim:      tt.trim,
+			}
+			exprFunc, err := createSplitStringFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			require.IsType(t, pcommon.Slice{}, result)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
+
+func TestSplitString_EmptySeparator(t *testing.T) {
+	args := &splitStringArguments[any]{Separator: ""}
+	_, err := createSplitStringFunction[any](ottl.FunctionContext{}, args)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "separator must not be empty")
+}
+
+func TestStripControlChars(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "ansi colors",
+			value:    "\x1b[31mERROR\x1b[0m GET /users",
+			expected: "ERROR GET /users",
+		},
+		{
+			name:     "bold and 256 colors",
+			value:    "\x1b[1;38;5;208mprocess\x1b[m job",
+			expected: "process job",
+		},
+		{
+			name:     "osc title sequence",
+			value:    "\x1b]0;title\x07worker",
+			expected: "worker",
+		},
+		{
+			name:     "control characters",
+			value:    "GET /users\r\n\x00",
+			expected: "GET /users",
+		},
+		{
+			name:     "clean input unchanged",
+			value:    "GET /users/{id}",
+			expected: "GET /users/{id}",
+		},
+		{
+			name:     "unicode kept",
+			value:    "café ünïcode",
+			expected: "café ünïcode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &stripControlCharsArguments[any]{
+				Value: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+			}
+			exprFunc, err := createStripControlCharsFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestBuildHTTPTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   any
+		scheme   any
+		host     any
+		path     any
+		expected any
+	}{
+		{
+			name:     "full set of inputs",
+			method:   "GET",
+			scheme:   "https",
+			host:     "api.example.com",
+			path:     "/users/123?expand=true",
+			expected: "GET https://api.example.com/users/{id}",
+		},
+		{
+			name:     "missing host",
+			method:   "POST",
+			scheme:   "https",
+			host:     nil,
+			path:     "/orders/550e8400-e29b-41d4-a716-446655440000/items",
+			expected: "POST /orders/{id}/items",
+		},
+		{
+			name:     "missing scheme",
+			method:   "GET",
+			host:     "api.example.com",
+			path:     "/health",
+			expected: "GET api.example.com/health",
+		},
+		{
+			name:     "missing path",
+			method:   "GET",
+			scheme:   "http",
+			host:     "localhost:8080",
+			expected: "GET http://localhost:8080/",
+		},
+		{
+			name:     "missing method",
+			path:     "/users/42",
+			expected: "/users/{id}",
+		},
+		{
+			name:     "missing host and path",
+			method:   "GET",
+			scheme:   "https",
+			expected: nil,
+		},
+	}
+
+	getter := func(value any) ottl.StringLikeGetter[any] {
+		return ottl.StandardStringLikeGetter[any]{
+			Getter: func(context.Context, any) (any, error) {
+				return value, nil
+			},
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &buildHTTPTargetArguments[any]{
+				Method: getter(tt.method),
+				Scheme: getter(tt.scheme),
+				Host:   getter(tt.host),
+				Path:   getter(tt.path),
+			}
+			exprFunc, err := createBuildHTTPTargetFunction[any](defaultPathNormalizer)(ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestNormalizePathExcept(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		preserve []int64
+		expected string
+	}{
+		{
+			name:     "preserve segment index 2",
+			path:     "/reports/123/2024/items/456",
+			preserve: []int64{2},
+			expected: "/reports/{id}/2024/items/{id}",
+		},
+		{
+			name:     "no preserved segments",
+			path:     "/reports/123/2024",
+			expected: "/reports/{id}/{id}",
+		},
+		{
+			name:     "uuid and hex segments",
+			path:     "/users/550e8400-e29b-41d4-a716-446655440000/objects/507f1f77bcf86cd799439011",
+			preserve: []int64{0},
+			expected: "/users/{id}/objects/{id}",
+		},
+		{
+			name:     "query removed and trailing slash kept",
+			path:     "/v2/orders/42/?expand=items",
+			preserve: []int64{0},
+			expected: "/v2/orders/{id}/",
+		},
+		{
+			name:     "index beyond path",
+			path:     "/orders/42",
+			preserve: []int64{5},
+			expected: "/orders/{id}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &normalizePathExceptArguments[any]{
+				Path: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.path, nil
+					},
+				},
+				Preserve: tt.preserve,
+			}
+			exprFunc, err := createNormalizePathExceptFunction[any](defaultPathNormalizer)(ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestProcessTraces_NormalizePathExcept(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "reports",
+					Priority:      100,
+					Condition:     `attributes["url.path"] != nil`,
+					OperationName: `NormalizePathExcept(attributes["url.path"], [2])`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET")
+	span.Attributes().PutStr("url.path", "/reports/123/2024")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assert.Equal(t, "/reports/{id}/2024", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestNormalizePathExcept_NegativeIndex(t *testing.T) {
+	args := &normalizePathExceptArguments[any]{Preserve: []int64{-1}}
+	_, err := createNormalizePathExceptFunction[any](defaultPathNormalizer)(ottl.FunctionContext{}, args)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must not be negative")
+}
+
+func TestOTTLFunctions_StandardConverters(t *testing.T) {
+	funcs := ottlFunctions[any](defaultPathNormalizer, nil, nil)
+
+	// Standard converters documented in the README as usable in rules
+	standard := []string{
+		"Concat", "ConvertCase", "Double", "Duration", "FormatTime", "Hour", "Int",
+		"IsMatch", "Len", "Split", "String", "Substring", "Time", "ToLowerCase",
+		"ToUpperCase", "TruncateTime", "UnixSeconds", "URL",
+	}
+	for _, name := range standard {
+		assert.Contains(t, funcs, name)
+	}
+
+	// Custom functions sit alongside them
+	custom := []string{
+		"AWSSpanName", "BuildHTTPTarget", "Bucket", "ClassifyService", "CollapseWhitespace", "ExtractHost", "ExtractPort", "FirstNonNil", "HashBucket", "HashString", "MapLookup",
+		"NormalizeHTTPMethod", "NormalizeIPs", "NormalizePath", "NormalizePathExcept", "NormalizeQuery", "ParentAttribute", "ParseCQL",
+		"ParseMongoCommand", "ParseSQL", "RegexReplace", "RemoveQueryParams", "SplitString", "StripControlChars",
+	}
+	for _, name := range custom {
+		assert.Contains(t, funcs, name)
+	}
+}
+
+func TestProcessTraces_TimeBucketedName(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "nightly_report",
+					Priority:      100,
+					Condition:     `attributes["job.name"] != nil`,
+					OperationName: `Concat([attributes["job.name"], FormatTime(TruncateTime(start_time, Duration("24h")), "%Y-%m-%d")], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("run")
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)))
+	span.Attributes().PutStr("job.name", "report")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assert.Equal(t, "report 2024-03-15", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestHashString(t *testing.T) {
+	// sha256("abc")
+	const abcDigest = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+
+	bytesValue := pcommon.NewValueBytes()
+	bytesValue.Bytes().FromRaw([]byte("abc"))
+
+	tests := []struct {
+		name     string
+		value    any
+		expected any
+	}{
+		{name: "string", value: "abc", expected: abcDigest},
+		{name: "bytes", value: []byte("abc"), expected: abcDigest},
+		{name: "bytes value", value: bytesValue, expected: abcDigest},
+		{name: "nil", value: nil, expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &hashStringArguments[any]{
+				Value: ottl.StandardGetSetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+			}
+			exprFunc, err := createHashStringFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestHashString_UnsupportedType(t *testing.T) {
+	args := &hashStringArguments[any]{
+		Value: ottl.StandardGetSetter[any]{
+			Getter: func(context.Context, any) (any, error) {
+				return int64(42), nil
+			},
+		},
+	}
+	exprFunc, err := createHashStringFunction[any](ottl.FunctionContext{}, args)
+	require.NoError(t, err)
+
+	_, err = exprFunc(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expects a string or bytes value")
+}
+
+func TestProcessTraces_HashStringBytesAttribute(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "hashed",
+					Priority:      100,
+					Condition:     `attributes["raw.id"] != nil`,
+					OperationName: `name`,
+					ExtraAttributes: map[string]string{
+						"raw.id.hash":  `HashString(attributes["raw.id"])`,
+						"text.id.hash": `HashString(attributes["text.id"])`,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("lookup")
+	span.Attributes().PutEmptyBytes("raw.id").FromRaw([]byte("user-42"))
+	span.Attributes().PutStr("text.id", "user-42")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Bytes and string values with the same content produce the same digest
+	attrs := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	rawHash, exists := attrs.Get("raw.id.hash")
+	require.True(t, exists)
+	textHash, exists := attrs.Get("text.id.hash")
+	require.True(t, exists)
+	assert.Len(t, rawHash.Str(), 64)
+	assert.Equal(t, textHash.Str(), rawHash.Str())
+}
+
+func TestRegexReplace(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		pattern     string
+		replacement string
+		expected    string
+	}{
+		{
+			name:        "numbered groups",
+			value:       "GET /api/v2/users/123",
+			pattern:     `^(\w+) /api/(v\d+)/(\w+)/\d+$`,
+			replacement: "$1 $3 ($2)",
+			expected:    "GET users (v2)",
+		},
+		{
+			name:        "named groups",
+			value:       "orders.eu-west-1.queue",
+			pattern:     `^(?P<topic>\w+)\.(?P<region>[\w-]+)\.queue$`,
+			replacement: "${topic} in ${region}",
+			expected:    "orders in eu-west-1",
+		},
+		{
+			name:        "every match replaced",
+			value:       "/a/1/b/2",
+			pattern:     `/\d+`,
+			replacement: "/{n}",
+			expected:    "/a/{n}/b/{n}",
+		},
+		{
+			name:        "no match",
+			value:       "health",
+			pattern:     `\d+`,
+			replacement: "{n}",
+			expected:    "health",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &regexReplaceArguments[any]{
+				Value: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+				Pattern:     tt.pattern,
+				Replacement: tt.replacement,
+			}
+			exprFunc, err := createRegexReplaceFunction[any](nil)(ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestRegexReplace_InvalidPattern(t *testing.T) {
+	args := &regexReplaceArguments[any]{Pattern: `(`}
+	_, err := createRegexReplaceFunction[any](nil)(ottl.FunctionContext{}, args)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "RegexReplace pattern")
+}
+
+func TestHashBucket(t *testing.T) {
+	const buckets = 8
+	
+	bucketOf := func(value any) any {
+		args := &hashBucketArguments[any]{
+			Value: ottl.StandardStringLikeGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return value, nil
+				},
+			},
+			N: buckets,
+		}
+		exprFunc, err := createHashBucketFunction[any](ottl.FunctionContext{}, args)
+		require.NoError(t, err)
+		
+		result, err := exprFunc(context.Background(), nil)
+		require.NoError(t, err)
+		return result
+	}
+	
+	// Assignment is deterministic
+	first := bucketOf("checkout-service")
+	assert.Equal(t, first, bucketOf("checkout-service"))
+	
+	// Non-string values are hashed by their string form, and nil stays nil
+	assert.Equal(t, bucketOf("42"), bucketOf(int64(42)))
+	assert.Nil(t, bucketOf(nil))
+	
+	// Buckets lie in 0..n-1 and are spread roughly evenly
+	counts := make([]int, buckets)
+	for i := 0; i < 8000; i++ {
+		bucket := bucketOf(fmt.Sprintf("user-%d", i)).(int64)
+		require.GreaterOrEqual(t, bucket, int64(0))
+		require.Less(t, bucket, int64(buckets))
+		counts[bucket]++
+	}
+	for bucket, count := range counts {
+		assert.InDelta(t, 1000, count, 150, "bucket %d", bucket)
+	}
+}
+
+func TestHashBucket_InvalidBucketCount(t *testing.T) {
+	for _, n := range []int64{0, -1} {
+		args := &hashBucketArguments[any]{N: n}
+		_, err := createHashBucketFunction[any](ottl.FunctionContext{}, args)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be greater than 0")
+	}
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "tabs and newlines",
+			value:    "SELECT *\n\tFROM users\r\n\tWHERE id = ?",
+			expected: "SELECT * FROM users WHERE id = ?",
+		},
+		{
+			name:     "repeated spaces trimmed",
+			value:    "   GET    /users  ",
+			expected: "GET /users",
+		},
+		{
+			name:     "clean input unchanged",
+			value:    "GET /users/{id}",
+			expected: "GET /users/{id}",
+		},
+		{
+			name:     "whitespace only",
+			value:    " \t\n ",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &collapseWhitespaceArguments[any]{
+				Value: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+			}
+			exprFunc, err := createCollapseWhitespaceFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestProcessTraces_CollapseWhitespaceWithParseSQL(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "db",
+					Priority:      100,
+					Condition:     `attributes["db.query.text"] != nil`,
+					OperationName: `ParseSQL(CollapseWhitespace(attributes["db.query.text"]))`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("query")
+	span.Attributes().PutStr("db.query.text", "\n  SELECT id\n\tFROM   users\n  WHERE id = ?\n")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT users", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestMapLookup(t *testing.T) {
+	mapping := ottl.StandardPMapGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return map[string]any{"200": "success", "404": "client_error", "500": "server_error"}, nil
+		},
+	}
+	fallback := ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "unknown", nil
+		},
+	}
+	
+	tests := []struct {
+		name         string
+		key          any
+		defaultValue ottl.Optional[ottl.Getter[any]]
+		expected     any
+	}{
+		{name: "hit", key: "404", expected: "client_error"},
+		{name: "numeric key", key: int64(500), expected: "server_error"},
+		{name: "miss with default", key: "302", defaultValue: ottl.NewTestingOptional[ottl.Getter[any]](fallback), expected: "unknown"},
+		{name: "miss without default", key: "302", expected: nil},
+		{name: "nil key with default", key: nil, defaultValue: ottl.NewTestingOptional[ottl.Getter[any]](fallback), expected: "unknown"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &mapLookupArguments[any]{
+				Key: ottl.StandardStringLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.key, nil
+					},
+				},
+				Mapping: mapping,
+				Default: tt.defaultValue,
+			}
+			exprFunc, err := createMapLookupFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+			
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestProcessTraces_MapLookup(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "grpc",
+					Priority:      100,
+					Condition:     `attributes["rpc.grpc.status_code"] != nil`,
+					OperationName: `attributes["rpc.method"]`,
+					ExtraAttributes: map[string]string{
+						"rpc.status_class": `MapLookup(attributes["rpc.grpc.status_code"], {"0": "ok", "5": "not_found"}, "error")`,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, code := range []int64{5, 13} {
+		span := spans.AppendEmpty()
+		span.SetName("rpc")
+		span.Attributes().PutStr("rpc.method", "GetUser")
+		span.Attributes().PutInt("rpc.grpc.status_code", code)
+	}
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	class, _ := resultSpans.At(0).Attributes().Get("rpc.status_class")
+	assert.Equal(t, "not_found", class.Str())
+	class, _ = resultSpans.At(1).Attributes().Get("rpc.status_class")
+	assert.Equal(t, "error", class.Str())
+}
+
+func TestNormalizeIPs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{
+			name:     "ipv4",
+			value:    "/proxy/10.0.0.5/health",
+			expected: "/proxy/{ip}/health",
+		},
+		{
+			name:     "ipv4 with port",
+			value:    "GET 192.168.1.20:8080",
+			expected: "GET {ip}:8080",
+		},
+		{
+			name:     "ipv6",
+			value:    "/proxy/2001:db8::8a2e:370:7334/health",
+			expected: "/proxy/{ip}/health",
+		},
+		{
+			name:     "bracketed ipv6 with port",
+			value:    "http://[::1]:9090/metrics",
+			expected: "http://[{ip}]:9090/metrics",
+		},
+		{
+			name:     "version numbers are not IPs",
+			value:    "/api/1.2.3/status",
+			expected: "/api/1.2.3/status",
+		},
+		{
+			name:     "too many octets",
+			value:    "/oid/1.3.6.1.4.1",
+			expected: "/oid/1.3.6.1.4.1",
+		},
+		{
+			name:     "octet out of range",
+			value:    "/proxy/10.0.0.256",
+			expected: "/proxy/10.0.0.256",
+		},
+		{
+			name:     "times are not IPs",
+			value:    "job 12:30:45",
+			expected: "job 12:30:45",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &normalizeIPsArguments[any]{
+				Value: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.value, nil
+					},
+				},
+			}
+			exprFunc, err := createNormalizeIPsFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestAWSSpanName(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  any
+		method   any
+		expected any
+	}{
+		{
+			name:     "dynamodb call",
+			service:  "DynamoDB",
+			method:   "GetItem",
+			expected: "DynamoDB.GetItem",
+		},
+		{
+			name:     "s3 call with missing method",
+			service:  "S3",
+			method:   nil,
+			expected: "S3",
+		},
+		{
+			name:     "blank method",
+			service:  "S3",
+			method:   " ",
+			expected: "S3",
+		},
+		{
+			name:     "missing service",
+			service:  nil,
+			method:   "GetItem",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &awsSpanNameArguments[any]{
+				Service: ottl.StandardStringLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.service, nil
+					},
+				},
+				Method: ottl.StandardStringLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.method, nil
+					},
+				},
+			}
+			exprFunc, err := createAWSSpanNameFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestProcessTraces_AWSSpanName(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "aws",
+					Priority:      100,
+					Condition:     `attributes["rpc.system"] == "aws-api"`,
+					OperationName: `AWSSpanName(attributes["rpc.service"], attributes["rpc.method"])`,
+					OperationType: `"aws"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	dynamo := spans.AppendEmpty()
+	dynamo.SetName("DynamoDB.GetItem orders-table")
+	dynamo.Attributes().PutStr("rpc.system", "aws-api")
+	dynamo.Attributes().PutStr("rpc.service", "DynamoDB")
+	dynamo.Attributes().PutStr("rpc.method", "GetItem")
+	dynamo.Attributes().PutStr("aws.dynamodb.table_names", "orders-table")
+	s3 := spans.AppendEmpty()
+	s3.SetName("S3 my-bucket")
+	s3.Attributes().PutStr("rpc.system", "aws-api")
+	s3.Attributes().PutStr("rpc.service", "S3")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	spans = result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "DynamoDB.GetItem", spans.At(0).Name())
+	assert.Equal(t, "S3", spans.At(1).Name())
+}
+
+func TestNormalizePathExcept_CleanPath(t *testing.T) {
+	args := &normalizePathExceptArguments[any]{
+		Path: ottl.StandardStringGetter[any]{
+			Getter: func(context.Context, any) (any, error) {
+				return "/reports//./123/../2024/items/456", nil
+			},
+		},
+		Preserve: []int64{1},
+	}
+	paths := newPathNormalizer(PathNormalizationConfig{CleanPath: true})
+	exprFunc, err := createNormalizePathExceptFunction[any](paths)(ottl.FunctionContext{}, args)
+	require.NoError(t, err)
+
+	// Segments are counted in the cleaned path
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "/reports/2024/items/{id}", result)
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "values are stripped and names sorted",
+			url:      "/search?q=test&limit=10&offset=20",
+			expected: "/search?limit&offset&q",
+		},
+		{
+			name:     "reordered parameters give the same key",
+			url:      "/search?offset=40&q=other&limit=5",
+			expected: "/search?limit&offset&q",
+		},
+		{
+			name:     "repeated parameters appear once",
+			url:      "/items?tag=a&tag=b&sort",
+			expected: "/items?sort&tag",
+		},
+		{
+			name:     "full URL with fragment",
+			url:      "https://example.com/docs?page=2&lang=en#install",
+			expected: "https://example.com/docs?lang&page",
+		},
+		{
+			name:     "empty parameters are skipped",
+			url:      "/search?&q=test&&=x",
+			expected: "/search?q",
+		},
+		{
+			name:     "empty query is removed",
+			url:      "/search?",
+			expected: "/search",
+		},
+		{
+			name:     "no query string",
+			url:      "/users/42",
+			expected: "/users/42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &normalizeQueryArguments[any]{
+				Path: ottl.StandardStringGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.url, nil
+					},
+				},
+			}
+			exprFunc, err := createNormalizeQueryFunction[any](ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestClassifyService(t *testing.T) {
+	cfg := ServiceClassificationConfig{
+		Rules: []ServiceClassificationRule{
+			{Pattern: "^payments-", Label: "critical"},
+			{Pattern: "^internal-", Label: "internal"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	services := newServiceClassifier(cfg, nil)
+
+	tests := []struct {
+		name        string
+		serviceName any
+		expected    string
+	}{
+		{
+			name:        "critical service",
+			serviceName: "payments-api",
+			expected:    "critical",
+		},
+		{
+			name:        "internal service",
+			serviceName: "internal-jobs",
+			expected:    "internal",
+		},
+		{
+			name:        "unmatched service gets the default",
+			serviceName: "checkout",
+			expected:    "unknown",
+		},
+		{
+			name:        "nil service name gets the default",
+			serviceName: nil,
+			expected:    "unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := &classifyServiceArguments[any]{
+				ServiceName: ottl.StandardStringLikeGetter[any]{
+					Getter: func(context.Context, any) (any, error) {
+						return tt.serviceName, nil
+					},
+				},
+			}
+			exprFunc, err := createClassifyServiceFunction[any](services)(ottl.FunctionContext{}, args)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// parentIndexKey is the context key under which the batch's parent index is stored
+type parentIndexKey struct{}
+
+// parentIndex maps span IDs to the spans of a batch so rules can read parent attributes
+type parentIndex map[pcommon.SpanID]ptrace.Span
+
+// buildParentIndex indexes every span of the batch by its span ID
+func buildParentIndex(td ptrace.Traces) parentIndex {
+	index := make(parentIndex)
+	resourceSpans := td.ResourceSpans()
+	for i := 0; i < resourceSpans.Len(); i++ {
+		scopeSpans := resourceSpans.At(i).ScopeSpans()
+		for j := 0; j < scopeSpans.Len(); j++ {
+			spans := scopeSpans.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				index[span.SpanID()] = span
+			}
+		}
+	}
+	return index
+}
+
+// withParentIndex returns a context carrying the parent index
+func withParentIndex(ctx context.Context, index parentIndex) context.Context {
+	return context.WithValue(ctx, parentIndexKey{}, index)
+}
+
+// parentSpan returns the parent of span when it is part of the indexed batch
+func parentSpan(ctx context.Context, span ptrace.Span) (ptrace.Span, bool) {
+	index, ok := ctx.Value(parentIndexKey{}).(parentIndex)
+	if !ok || span.ParentSpanID().IsEmpty() {
+		return ptrace.Span{}, false
+	}
+	parent, ok := index[span.ParentSpanID()]
+	return parent, ok
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestProcessTraces_ParentAttribute(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:      true,
+			Mode:         ModeEnforce,
+			IndexParents: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "rpc_handler",
+					Priority:      100,
+					Condition:     `attributes["rpc.method"] != nil and ParentAttribute("rpc.service") != nil`,
+					OperationName: `Concat([ParentAttribute("rpc.service"), attributes["rpc.method"]], "/")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+
+	// The child comes first to show the index does not depend on span order
+	child := spans.AppendEmpty()
+	child.SetName("handler")
+	child.SetSpanID(pcommon.SpanID([8]byte{2}))
+	child.SetParentSpanID(pcommon.SpanID([8]byte{1}))
+	child.Attributes().PutStr("rpc.method", "GetUser")
+
+	parent := spans.AppendEmpty()
+	parent.SetName("dispatch")
+	parent.SetSpanID(pcommon.SpanID([8]byte{1}))
+	parent.Attributes().PutStr("rpc.service", "UserService")
+
+	// The parent of this span is not part of the batch
+	orphan := spans.AppendEmpty()
+	orphan.SetName("handler")
+	orphan.SetSpanID(pcommon.SpanID([8]byte{3}))
+	orphan.SetParentSpanID(pcommon.SpanID([8]byte{9}))
+	orphan.Attributes().PutStr("rpc.method", "GetUser")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "UserService/GetUser", resultSpans.At(0).Name())
+	assert.Equal(t, "dispatch", resultSpans.At(1).Name())
+	assert.Equal(t, "handler", resultSpans.At(2).Name())
+}
+
+func TestParentAttribute_RequiresIndexParents(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "rpc_handler",
+					Condition:     `ParentAttribute("rpc.service") != nil`,
+					OperationName: `"test"`,
+				},
+			},
+		},
+	}
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rule rpc_handler uses ParentAttribute, which requires index_parents")
+}
+
+func TestParentAttribute_StringLiteralDoesNotRequireIndexParents(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "literal",
+					Condition:     `name == "see ParentAttribute(x)"`,
+					OperationName: `"test"`,
+				},
+			},
+		},
+	}
+
+	require.NoError(t, cfg.Validate())
+}
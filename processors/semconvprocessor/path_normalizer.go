@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// pathUUIDPattern matches UUIDs anywhere in a path
+var pathUUIDPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// pathHexPattern matches hex strings such as MongoDB ObjectIds
+var pathHexPattern = regexp.MustCompile(`/[0-9a-fA-F]{16,}(/|$)`)
+
+// defaultPathNormalizer normalizes paths with the default path_normalization settings
+var defaultPathNormalizer = newPathNormalizer(PathNormalizationConfig{})
+
+// truncatedPathSuffix marks a path cut to max_depth segments
+const truncatedPathSuffix = "/..."
+
+// pathHexSegmentPattern matches whole segments that are hex strings
+var pathHexSegmentPattern = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+
+// pathNormalizer replaces ID-like path segments with {id}, or with {uuid}, {num}
+// and {hex} when typed placeholders are enabled
+type pathNormalizer struct {
+	numericPattern        *regexp.Regexp // Numeric IDs within a path
+	numericSegmentPattern *regexp.Regexp // Whole segments that are numeric IDs
+	uuidPlaceholder       string
+	numPlaceholder        string
+	hexPlaceholder        string
+	maxDepth              int  // Segments kept by truncate; 0 keeps all
+	cleanPath             bool // Collapse // and resolve . and .. segments first
+}
+
+// newPathNormalizer builds a normalizer from the path_normalization settings
+func newPathNormalizer(cfg PathNormalizationConfig) *pathNormalizer {
+	minDigits := cfg.MinIDDigits
+	if minDigits < 1 {
+		minDigits = 1
+	}
+
+	n := &pathNormalizer{
+		numericPattern:        regexp.MustCompile(fmt.Sprintf(`/\d{%d,}(/|$)`, minDigits)),
+		numericSegmentPattern: regexp.MustCompile(fmt.Sprintf(`^\d{%d,}$`, minDigits)),
+		uuidPlaceholder:       "{id}",
+		numPlaceholder:        "{id}",
+		hexPlaceholder:        "{id}",
+		maxDepth:              cfg.MaxDepth,
+		cleanPath:             cfg.CleanPath,
+	}
+	if cfg.TypedPlaceholders {
+		n.uuidPlaceholder = "{uuid}"
+		n.numPlaceholder = "{num}"
+		n.hexPlaceholder = "{hex}"
+	}
+	return n
+}
+
+// normalize strips the query and replaces ID-like path segments with placeholders
+func (n *pathNormalizer) normalize(pathStr string) string {
+	// Remove query parameters first
+	if idx := strings.Index(pathStr, "?"); idx != -1 {
+		pathStr = pathStr[:idx]
+	}
+	pathStr = n.clean(pathStr)
+
+	// Replace UUIDs
+	pathStr = pathUUIDPattern.ReplaceAllString(pathStr, n.uuidPlaceholder)
+
+	// Replace hex strings (like MongoDB ObjectIds); long numbers also match, but are numbers
+	pathStr = pathHexPattern.ReplaceAllStringFunc(pathStr, func(match string) string {
+		segment := strings.Trim(match, "/")
+		placeholder := n.hexPlaceholder
+		if strings.Trim(segment, "0123456789") == "" {
+			placeholder = n.numPlaceholder
+		}
+		return strings.Replace(match, segment, placeholder, 1)
+	})
+
+	// Replace numeric IDs
+	pathStr = n.numericPattern.ReplaceAllString(pathStr, "/"+n.numPlaceholder+"$1")
+
+	return n.truncate(pathStr)
+}
+
+// clean collapses duplicate slashes and resolves . and .. segments like path.Clean,
+// but keeps a leading and trailing slash and drops .. segments that would climb
+// above the root instead of keeping them
+func (n *pathNormalizer) clean(pathStr string) string {
+	if !n.cleanPath || pathStr == "" {
+		return pathStr
+	}
+
+	segments := strings.Split(pathStr, "/")
+	kept := segments[:0]
+	for _, segment := range segments {
+		switch segment {
+		case "", ".":
+		case "..":
+			if len(kept) > 0 {
+				kept = kept[:len(kept)-1]
+			}
+		default:
+			kept = append(kept, segment)
+		}
+	}
+
+	cleaned := strings.Join(kept, "/")
+	if strings.HasPrefix(pathStr, "/") {
+		cleaned = "/" + cleaned
+	}
+	if strings.HasSuffix(pathStr, "/") && len(kept) > 0 {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// truncate keeps the first maxDepth segments of a path and appends /... when more
+// segments follow, so very deep paths collapse into one name
+func (n *pathNormalizer) truncate(pathStr string) string {
+	if n.maxDepth <= 0 {
+		return pathStr
+	}
+
+	// The segments after the leading slash are counted; a trailing slash is no segment
+	rest := strings.TrimPrefix(pathStr, "/")
+	segments := strings.SplitN(rest, "/", n.maxDepth+1)
+	if len(segments) <= n.maxDepth || segments[n.maxDepth] == "" {
+		return pathStr
+	}
+	prefix := pathStr[:len(pathStr)-len(rest)]
+	return prefix + strings.Join(segments[:n.maxDepth], "/") + truncatedPathSuffix
+}
+
+// normalizeSegment replaces ID-like content of a single path segment with a placeholder
+func (n *pathNormalizer) normalizeSegment(segment string) string {
+	if n.numericSegmentPattern.MatchString(segment) {
+		return n.numPlaceholder
+	}
+	if pathHexSegmentPattern.MatchString(segment) {
+		return n.hexPlaceholder
+	}
+	return pathUUIDPattern.ReplaceAllString(segment, n.uuidPlaceholder)
+}
@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestPathNormalizer_MinIDDigits(t *testing.T) {
+	tests := []struct {
+		name        string
+		minIDDigits int
+		path        string
+		expected    string
+	}{
+		{
+			name:     "default replaces single digits",
+			path:     "/api/v2/users/7",
+			expected: "/api/v2/users/{id}",
+		},
+		{
+			name:        "threshold 1 replaces single digits",
+			minIDDigits: 1,
+			path:        "/shards/3/users/42",
+			expected:    "/shards/{id}/users/{id}",
+		},
+		{
+			name:        "threshold 2 preserves single digits",
+			minIDDigits: 2,
+			path:        "/shards/3/users/42",
+			expected:    "/shards/3/users/{id}",
+		},
+		{
+			name:        "threshold does not affect UUIDs",
+			minIDDigits: 4,
+			path:        "/users/550e8400-e29b-41d4-a716-446655440000/orders/123",
+			expected:    "/users/{id}/orders/123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := newPathNormalizer(PathNormalizationConfig{MinIDDigits: tt.minIDDigits})
+			assert.Equal(t, tt.expected, paths.normalize(tt.path))
+		})
+	}
+}
+
+func TestPathNormalizer_MaxDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "deep path is truncated",
+			path:     "/a/b/c/d/e/f/g",
+			expected: "/a/b/c/...",
+		},
+		{
+			name:     "IDs are replaced before truncating",
+			path:     "/users/42/orders/7/items/3",
+			expected: "/users/{id}/orders/...",
+		},
+		{
+			name:     "shallow path is intact",
+			path:     "/users/42",
+			expected: "/users/{id}",
+		},
+		{
+			name:     "path at the limit is intact",
+			path:     "/a/b/c",
+			expected: "/a/b/c",
+		},
+		{
+			name:     "trailing slash is no segment",
+			path:     "/a/b/c/",
+			expected: "/a/b/c/",
+		},
+		{
+			name:     "root is intact",
+			path:     "/",
+			expected: "/",
+		},
+	}
+
+	paths := newPathNormalizer(PathNormalizationConfig{MaxDepth: 3})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, paths.normalize(tt.path))
+		})
+	}
+}
+
+func TestPathNormalizer_TypedPlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "uuid",
+			path:     "/users/550e8400-e29b-41d4-a716-446655440000",
+			expected: "/users/{uuid}",
+		},
+		{
+			name:     "numeric",
+			path:     "/orders/12345",
+			expected: "/orders/{num}",
+		},
+		{
+			name:     "hex",
+			path:     "/documents/507f1f77bcf86cd799439011/versions",
+			expected: "/documents/{hex}/versions",
+		},
+		{
+			name:     "long number is numeric",
+			path:     "/accounts/12345678901234567890",
+			expected: "/accounts/{num}",
+		},
+		{
+			name:     "mixed",
+			path:     "/users/550e8400-e29b-41d4-a716-446655440000/orders/7",
+			expected: "/users/{uuid}/orders/{num}",
+		},
+	}
+
+	typed := newPathNormalizer(PathNormalizationConfig{TypedPlaceholders: true})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, typed.normalize(tt.path))
+		})
+	}
+
+	assert.Equal(t, "{uuid}", typed.normalizeSegment("550e8400-e29b-41d4-a716-446655440000"))
+	assert.Equal(t, "{num}", typed.normalizeSegment("42"))
+	assert.Equal(t, "{hex}", typed.normalizeSegment("507f1f77bcf86cd799439011"))
+
+	// {id} stays the default for every type
+	assert.Equal(t, "/users/{id}/documents/{id}/orders/{id}",
+		defaultPathNormalizer.normalize("/users/550e8400-e29b-41d4-a716-446655440000/documents/507f1f77bcf86cd799439011/orders/7"))
+}
+
+func TestPathNormalizer_CleanPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{
+			name:     "duplicate slashes",
+			path:     "/api//users///42",
+			expected: "/api/users/{id}",
+		},
+		{
+			name:     "dot segments",
+			path:     "/api/./users/./42",
+			expected: "/api/users/{id}",
+		},
+		{
+			name:     "dot-dot segments",
+			path:     "/api/users/123/../456",
+			expected: "/api/users/{id}",
+		},
+		{
+			name:     "combined",
+			path:     "/api//users/./123/../456",
+			expected: "/api/users/{id}",
+		},
+		{
+			name:     "dot-dot never climbs above the root",
+			path:     "/../../etc/passwd",
+			expected: "/etc/passwd",
+		},
+		{
+			name:     "trailing slash is kept",
+			path:     "/users//",
+			expected: "/users/",
+		},
+		{
+			name:     "query is removed first",
+			path:     "/search/./results?next=../x",
+			expected: "/search/results",
+		},
+		{
+			name:     "normal path is unchanged",
+			path:     "/api/v1/users/{id}/orders",
+			expected: "/api/v1/users/{id}/orders",
+		},
+		{
+			name:     "root is unchanged",
+			path:     "/",
+			expected: "/",
+		},
+	}
+
+	paths := newPathNormalizer(PathNormalizationConfig{CleanPath: true})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, paths.normalize(tt.path))
+		})
+	}
+
+	// Cleaning is opt-in
+	assert.Equal(t, "/api//users/./{id}/../{id}", defaultPathNormalizer.normalize("/api//users/./123/../456"))
+}
+
+func TestPathNormalizer_NormalizeSegment(t *testing.T) {
+	paths := newPathNormalizer(PathNormalizationConfig{MinIDDigits: 2})
+
+	assert.Equal(t, "3", paths.normalizeSegment("3"))
+	assert.Equal(t, "{id}", paths.normalizeSegment("42"))
+	assert.Equal(t, "{id}", paths.normalizeSegment("507f1f77bcf86cd799439011"))
+	assert.Equal(t, "users", paths.normalizeSegment("users"))
+}
+
+func TestProcessTraces_PathNormalizationMinIDDigits(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:           true,
+			Mode:              ModeEnforce,
+			PathNormalization: PathNormalizationConfig{MinIDDigits: 2},
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["url.path"] != nil`,
+					OperationName: `NormalizePath(attributes["url.path"])`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("request")
+	span.Attributes().PutStr("url.path", "/api/2/orders/1234")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/2/orders/{id}", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
@@ -6,7 +6,15 @@ package semconvprocessor
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
@@ -14,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -22,15 +31,86 @@ import (
 	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
 )
 
+// conformantAttribute marks spans found non-conforming in audit mode
+const conformantAttribute = "semconv.conformant"
+
+// nameMappingsRuleID is reported as the rule_id for spans renamed via name_mappings
+const nameMappingsRuleID = "name_mappings"
+
+// HTTP method attributes rewritten when normalize_http_method is enabled
+const (
+	httpRequestMethodAttribute         = "http.request.method"
+	httpRequestMethodOriginalAttribute = "http.request.method_original"
+)
+
+// messagingDestinationAttributes are rewritten by the destination normalizers
+var messagingDestinationAttributes = []string{"messaging.destination.name", "messaging.destination"}
+
+// pathAttributes are read, in order, by emit_normalized_path_attribute; http.target
+// is the deprecated form of url.path and may still carry a query string
+var pathAttributes = []string{"url.path", "http.target"}
+
+// errorEventName is the span event attached when a rule fails to evaluate
+const errorEventName = "semconv.error"
+
+// maxErrorEventsPerBatch caps error events so a broken rule cannot flood a batch
+const maxErrorEventsPerBatch = 10
+
+// maxAbsorbedNamesPerRule bounds the span names remembered per rule for the
+// absorption gauge; once reached, the gauge stops growing for that rule
+const maxAbsorbedNamesPerRule = 10000
+
+// maxDistinctOperationTypes bounds the operation types tracked per batch; a gauge
+// stuck at this value means rules produce far more types than intended
+const maxDistinctOperationTypes = 1000
+
+// sampledTraceFlag is the W3C trace flags bit marking a span as sampled
+const sampledTraceFlag = 0x01
+
+// batchState carries per-batch bookkeeping through span processing
+type batchState struct {
+	errorEvents       int              // semconv.error events attached so far
+	unknownAttributes map[string]int64 // Occurrences of span attribute keys missing from the semconv registry
+	operationTypes    map[string]bool  // Distinct operation types, bounded by maxDistinctOperationTypes
+}
+
 // semconvProcessor is the implementation of the semconv processor
 type semconvProcessor struct {
-	logger         *zap.Logger
-	config         *Config
-	telemetry      *metadata.TelemetryBuilder
-	compiledRules  []compiledRule
-	parser         ottl.Parser[ottlspan.TransformContext]
-	spanNameCount  map[string]int64 // For benchmark mode - tracks occurrences
-	operationCount map[string]int64 // For benchmark mode - tracks occurrences
+	logger               *zap.Logger
+	config               *Config
+	telemetry            *metadata.TelemetryBuilder
+	compiledRules        []compiledRule
+	dropConditions       []*ottl.Condition[ottlspan.TransformContext] // Spans matching any of them are removed
+	spanKindRules        []compiledSpanKindRule                       // Correct span kinds before rules are evaluated
+	parser               ottl.Parser[ottlspan.TransformContext]
+	spanNameCount        map[string]int64               // For benchmark mode - tracks occurrences
+	operationCount       map[string]int64               // For benchmark mode - tracks occurrences
+	keyedSpanNameCount   map[string]map[string]int64    // For benchmark mode - occurrences per benchmark key
+	keyedOperationCount  map[string]map[string]int64    // For benchmark mode - occurrences per benchmark key
+	ruleAbsorbedNames    map[string]map[string]struct{} // For benchmark mode - unique span names absorbed per rule
+	nameMappingCount     map[nameMapping]int64          // For benchmark_export_path - occurrences per original and operation name
+	benchmarkMu          sync.Mutex                     // Guards the benchmark maps against the flush goroutine
+	allowedResourceAttrs map[string]bool                // Resource attributes kept when an allowlist is configured
+	reportUnknownAttrs   bool                           // Count span attribute keys missing from the semconv registry
+	extraKnownAttrs      map[string]bool                // Keys from known_attributes and the processor's own attributes, treated as part of the registry
+	unknownAttrKeys      map[string]bool                // Distinct unknown keys reported so far, bounded by maxUnknownAttributeKeys
+	unknownAttrMu        sync.Mutex                     // Guards unknownAttrKeys across concurrent batches
+	destinationRewrites  []compiledRewrite              // Applied to messaging destination names before rules
+	scopeRewrites        []compiledRewrite              // Applied to instrumentation scope names before rules
+	genericName          *regexp.Regexp                 // Generic span names replaced in enrich mode, nil when disabled
+	logRules             []compiledLogRule              // Derive operation names from log bodies
+	logPaths             *pathNormalizer                // Normalizes paths extracted by the log rules
+	ruleMatches          map[string]int64               // Matches per compiled rule in the current rule_match_window
+	ruleMatchMu          sync.Mutex                     // Guards ruleMatches against the window goroutine
+	ruleWindowSpans      atomic.Int64                   // Spans evaluated against the rules in the current window
+	regexes              *regexGuard                    // Skips user-supplied regexes on oversized inputs
+	paths                *pathNormalizer                // Shared by the path functions and emit_normalized_path_attribute
+	version              string                         // Written by stamp_provenance
+	schema               *schemaMigrator                // Renames attributes to the schema_migration target, nil when disabled
+	services             *serviceClassifier             // Labels services for ClassifyService and service_classification
+	durationBatches      map[string]*atomic.Uint64      // Batches seen per signal, for metrics_sample_ratio
+	stopFlush            context.CancelFunc
+	flushDone            chan struct{}
 }
 
 // compiledRule represents a compiled OTTL rule
@@ -39,8 +119,16 @@ type compiledRule struct {
 	Priority        int
 	SpanKind        []string // Allowed span kinds (empty means all)
 	Condition       ottl.Condition[ottlspan.TransformContext]
-	OperationName   *ottl.ValueExpression[ottlspan.TransformContext]
+	OperationNames  []*ottl.ValueExpression[ottlspan.TransformContext] // Candidates, tried in order
+	Template        *operationTemplate                               // Used instead of OperationNames when set
 	OperationType   *ottl.ValueExpression[ottlspan.TransformContext] // Optional
+	ExtraAttributes []compiledAttribute                              // Optional, sorted by name
+}
+
+// compiledAttribute is an extra attribute written when a rule matches
+type compiledAttribute struct {
+	Name  string
+	Value *ottl.ValueExpression[ottlspan.TransformContext]
 }
 
 // newSemconvProcessor creates a new semconv processor
@@ -51,16 +139,66 @@ func newSemconvProcessor(logger *zap.Logger, config *Config, telemetry *metadata
 		telemetry: telemetry,
 	}
 	
+	sp.durationBatches = make(map[string]*atomic.Uint64, len(validSignals))
+	for signal := range validSignals {
+		sp.durationBatches[signal] = &atomic.Uint64{}
+	}
+	
 	if config.Benchmark {
 		sp.spanNameCount = make(map[string]int64)
 		sp.operationCount = make(map[string]int64)
+		sp.ruleAbsorbedNames = make(map[string]map[string]struct{})
+		if config.BenchmarkExportPath != "" {
+			sp.nameMappingCount = make(map[nameMapping]int64)
+		}
+		if config.BenchmarkKeyAttribute != "" {
+			sp.keyedSpanNameCount = make(map[string]map[string]int64)
+			sp.keyedOperationCount = make(map[string]map[string]int64)
+		}
 	}
 	
-	// Initialize OTTL parser if span processing is enabled
-	if config.SpanProcessing.Enabled {
+	sp.regexes = &regexGuard{
+		maxBytes: config.MaxRegexInputBytes,
+		skipped:  telemetry.ProcessorSemconvRegexInputsSkipped,
+	}
+	
+	if err := config.ServiceClassification.Validate(); err != nil {
+		return nil, err
+	}
+	sp.services = newServiceClassifier(config.ServiceClassification, sp.regexes)
+	
+	if len(config.ResourceAttributeAllowlist) > 0 {
+		sp.allowedResourceAttrs = make(map[string]bool, len(config.ResourceAttributeAllowlist))
+		for _, key := range config.ResourceAttributeAllowlist {
+			sp.allowedResourceAttrs[key] = true
+		}
+	}
+	
+	// Unknown attributes are reported alongside the other benchmark and audit metrics
+	if config.Benchmark || (config.SpanProcessing.Enabled && config.SpanProcessing.Mode == ModeAudit) {
+		sp.reportUnknownAttrs = true
+		sp.unknownAttrKeys = make(map[string]bool)
+		sp.extraKnownAttrs = make(map[string]bool, len(config.KnownAttributes))
+		for _, key := range config.KnownAttributes {
+			sp.extraKnownAttrs[key] = true
+		}
+		for _, key := range config.processorAttributes() {
+			sp.extraKnownAttrs[key] = true
+		}
+	}
+	
+	// Initialize OTTL parser if span processing is enabled; drop conditions need it
+	// even when it is not
+	if config.SpanProcessing.Enabled || len(config.SpanProcessing.DropConditions) > 0 {
+		sp.paths = newPathNormalizer(config.SpanProcessing.PathNormalization)
+		
 		// Create parser with custom functions and telemetry settings
+		functions := ottlFunctions[ottlspan.TransformContext](sp.paths, sp.regexes, sp.services)
+		for _, name := range config.SpanProcessing.DisabledFunctions {
+			delete(functions, name)
+		}
 		parser, err := ottlspan.NewParser(
-			ottlFunctions[ottlspan.TransformContext](),
+			functions,
 			set,
 		)
 		if err != nil {
@@ -68,20 +206,162 @@ func newSemconvProcessor(logger *zap.Logger, config *Config, telemetry *metadata
 		}
 		sp.parser = parser
 		
+		if err := sp.compileDropConditions(); err != nil {
+			return nil, err
+		}
+	}
+	
+	if config.SpanProcessing.Enabled {
 		// Compile rules
 		if err := sp.compileRules(); err != nil {
 			return nil, fmt.Errorf("failed to compile rules: %w", err)
 		}
+		sp.logRuleSummary()
+		
+		if config.RuleMatchWindow > 0 {
+			sp.ruleMatches = make(map[string]int64, len(sp.compiledRules))
+			for _, rule := range sp.compiledRules {
+				sp.ruleMatches[rule.ID] = 0
+			}
+		}
+		
+		if err := sp.compileSpanKindRules(); err != nil {
+			return nil, err
+		}
+		
+		destinationRewrites, err := compileRewrites(config.SpanProcessing.DestinationNormalizers)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile destination_normalizers: %w", err)
+		}
+		sp.destinationRewrites = destinationRewrites
+		
+		scopeRewrites, err := compileRewrites(config.SpanProcessing.ScopeRewrites)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile scope_rewrites: %w", err)
+		}
+		sp.scopeRewrites = scopeRewrites
+		
+		if config.SpanProcessing.StampProvenance {
+			sp.version = processorVersion()
+		}
+		
+		if config.SpanProcessing.EnrichSetNameIfGeneric {
+			genericName, err := regexp.Compile(config.SpanProcessing.GenericNamePattern)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile generic_name_pattern: %w", err)
+			}
+			sp.genericName = genericName
+		}
+	}
+	
+	if config.SchemaMigration.enabled() {
+		if err := config.SchemaMigration.Validate(); err != nil {
+			return nil, err
+		}
+		schema, err := newSchemaMigrator(logger, config.SchemaMigration.schema, config.SchemaMigration.TargetVersion, config.SchemaMigration.TargetVersionAttribute, config.SchemaMigration.VersionAttribute)
+		if err != nil {
+			return nil, err
+		}
+		sp.schema = schema
+	}
+	
+	if config.LogProcessing.Enabled {
+		logRules, err := compileLogRules(config.LogProcessing.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile log_processing rules: %w", err)
+		}
+		sp.logRules = logRules
+		sp.logPaths = newPathNormalizer(config.LogProcessing.PathNormalization)
 	}
 	
 	return sp, nil
 }
 
+// start launches the periodic benchmark flush and the rule match window when an
+// interval is configured
+func (sp *semconvProcessor) start(_ context.Context, _ component.Host) error {
+	benchmarkFlush := sp.config.Benchmark && sp.config.BenchmarkInterval > 0
+	if !benchmarkFlush && sp.ruleMatches == nil {
+		return nil
+	}
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	sp.stopFlush = cancel
+	sp.flushDone = make(chan struct{})
+	
+	go func() {
+		defer close(sp.flushDone)
+		
+		// A nil channel never fires, so a disabled task is never selected
+		var benchmarkTick, ruleWindowTick <-chan time.Time
+		if benchmarkFlush {
+			ticker := time.NewTicker(sp.config.BenchmarkInterval)
+			defer ticker.Stop()
+			benchmarkTick = ticker.C
+		}
+		if sp.ruleMatches != nil {
+			ticker := time.NewTicker(sp.config.RuleMatchWindow)
+			defer ticker.Stop()
+			ruleWindowTick = ticker.C
+		}
+		
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-benchmarkTick:
+				sp.recordBenchmarkMetrics(ctx)
+			case <-ruleWindowTick:
+				sp.reportUnmatchedRules(ctx)
+			}
+		}
+	}()
+	
+	return nil
+}
+
+// shutdown stops the periodic tasks, waits for them to exit and writes the
+// benchmark export if one is configured
+func (sp *semconvProcessor) shutdown(ctx context.Context) error {
+	if sp.stopFlush != nil {
+		sp.stopFlush()
+		select {
+		case <-sp.flushDone:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	
+	// Export once no more batches are counted. The processors of the other signals
+	// share the config but count no spans, so they must not overwrite the file
+	if sp.nameMappingCount != nil && sp.hasBenchmarkCounts() {
+		return sp.exportBenchmark()
+	}
+	return nil
+}
+
 // compileRules compiles OTTL expressions from configuration
 func (sp *semconvProcessor) compileRules() error {
 	sp.compiledRules = make([]compiledRule, 0, len(sp.config.SpanProcessing.Rules))
 	
+	// Rules copied from templates often share expressions; parse each text once
+	cache := newExpressionCache(sp.parser)
+	
 	for _, rule := range sp.config.SpanProcessing.Rules {
+		if !rule.isEnabled() {
+			sp.logger.Debug("rule disabled, skipping",
+				zap.String("rule_id", rule.ID))
+			continue
+		}
+		
+		// Rules outside the priority window are switched off and not compiled
+		if !sp.config.SpanProcessing.inPriorityWindow(rule.Priority) {
+			sp.logger.Debug("rule outside priority window, skipping",
+				zap.String("rule_id", rule.ID),
+				zap.Int("priority", rule.Priority))
+			continue
+		}
+		
 		compiled := compiledRule{
 			ID:       rule.ID,
 			Priority: rule.Priority,
@@ -89,42 +369,163 @@ func (sp *semconvProcessor) compileRules() error {
 		}
 		
 		// Compile condition
-		condition, err := sp.parser.ParseCondition(rule.Condition)
+		condition, err := cache.condition(rule.Condition)
 		if err != nil {
 			return fmt.Errorf("failed to parse condition for rule %s: %w", rule.ID, err)
 		}
 		compiled.Condition = *condition
 		
-		// Parse operation name as a value expression
-		operationName, err := sp.parser.ParseValueExpression(rule.OperationName)
-		if err != nil {
-			return fmt.Errorf("failed to parse operation_name for rule %s: %w", rule.ID, err)
+		// A template replaces the operation name expressions
+		if rule.Template != "" {
+			template, err := parseOperationTemplate(rule.Template)
+			if err != nil {
+				return fmt.Errorf("failed to parse template for rule %s: %w", rule.ID, err)
+			}
+			compiled.Template = template
+		}
+		
+		// Parse operation name candidates as value expressions; a single
+		// operation_name is a list of one
+		candidates := rule.OperationNameCandidates
+		if rule.OperationName != "" {
+			candidates = []string{rule.OperationName}
+		}
+		for _, candidate := range candidates {
+			operationName, err := cache.value(candidate)
+			if err != nil {
+				return fmt.Errorf("failed to parse operation_name for rule %s: %w", rule.ID, err)
+			}
+			compiled.OperationNames = append(compiled.OperationNames, operationName)
 		}
-		compiled.OperationName = operationName
 		
 		// Parse operation type as a value expression (optional)
 		if rule.OperationType != "" {
-			operationType, err := sp.parser.ParseValueExpression(rule.OperationType)
+			operationType, err := cache.value(rule.OperationType)
 			if err != nil {
 				return fmt.Errorf("failed to parse operation_type for rule %s: %w", rule.ID, err)
 			}
 			compiled.OperationType = operationType
 		}
 		
+		// Parse extra attribute expressions (optional). They are applied in sorted order
+		// so expressions that read an attribute written by another one give reproducible results
+		names := make([]string, 0, len(rule.ExtraAttributes))
+		for name := range rule.ExtraAttributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			value, err := cache.value(rule.ExtraAttributes[name])
+			if err != nil {
+				return fmt.Errorf("failed to parse extra attribute %s for rule %s: %w", name, rule.ID, err)
+			}
+			compiled.ExtraAttributes = append(compiled.ExtraAttributes, compiledAttribute{Name: name, Value: value})
+		}
+		
 		sp.compiledRules = append(sp.compiledRules, compiled)
 	}
 	
 	return nil
 }
 
+// logRuleSummary logs the outcome of rule compilation so operators can verify that the
+// loaded configuration matches their intent. Rules that fail to compile (for example
+// because they call an unknown function) abort startup with an error instead.
+func (sp *semconvProcessor) logRuleSummary() {
+	sp.logger.Info("compiled span processing rules",
+		zap.Int("rules_compiled", len(sp.compiledRules)),
+		zap.Int("rules_skipped", len(sp.config.SpanProcessing.Rules)-len(sp.compiledRules)),
+		zap.String("mode", string(sp.config.SpanProcessing.Mode)),
+		zap.String("match_strategy", string(sp.config.SpanProcessing.MatchStrategy)))
+	
+	// Rules are listed in evaluation order
+	for _, rule := range sp.compiledRules {
+		sp.logger.Debug("compiled rule",
+			zap.String("rule_id", rule.ID),
+			zap.Int("priority", rule.Priority))
+	}
+}
+
+// expressionCache reuses parsed OTTL expressions for identical expression text.
+// Parsed expressions are immutable, so rules can share them.
+type expressionCache struct {
+	parser     ottl.Parser[ottlspan.TransformContext]
+	conditions map[string]*ottl.Condition[ottlspan.TransformContext]
+	values     map[string]*ottl.ValueExpression[ottlspan.TransformContext]
+}
+
+func newExpressionCache(parser ottl.Parser[ottlspan.TransformContext]) *expressionCache {
+	return &expressionCache{
+		parser:     parser,
+		conditions: make(map[string]*ottl.Condition[ottlspan.TransformContext]),
+		values:     make(map[string]*ottl.ValueExpression[ottlspan.TransformContext]),
+	}
+}
+
+// condition returns the parsed condition for expr, parsing it on first use
+func (c *expressionCache) condition(expr string) (*ottl.Condition[ottlspan.TransformContext], error) {
+	if condition, ok := c.conditions[expr]; ok {
+		return condition, nil
+	}
+	condition, err := c.parser.ParseCondition(expr)
+	if err != nil {
+		return nil, err
+	}
+	c.conditions[expr] = condition
+	return condition, nil
+}
+
+// value returns the parsed value expression for expr, parsing it on first use
+func (c *expressionCache) value(expr string) (*ottl.ValueExpression[ottlspan.TransformContext], error) {
+	if value, ok := c.values[expr]; ok {
+		return value, nil
+	}
+	value, err := c.parser.ParseValueExpression(expr)
+	if err != nil {
+		return nil, err
+	}
+	c.values[expr] = value
+	return value, nil
+}
+
+// shouldProcessSpan reports whether the span passes the sampling filters
+func (sp *semconvProcessor) shouldProcessSpan(span ptrace.Span) bool {
+	if sp.config.SpanProcessing.OnlySampled && span.Flags()&sampledTraceFlag == 0 {
+		return false
+	}
+	
+	// Spans marked for dropping by an upstream sampler are not worth the work
+	if skip := sp.config.SpanProcessing.SkipIfAttribute; skip.Key != "" {
+		if value, exists := span.Attributes().Get(skip.Key); exists && value.AsString() == skip.Value {
+			return false
+		}
+	}
+	return true
+}
+
 // processTraces processes the incoming traces
 func (sp *semconvProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
-	if !sp.config.Enabled {
+	if !sp.config.Enabled || !sp.config.signalEnabled("traces") {
 		return td, nil
 	}
 
 	start := time.Now()
 	spanCount := 0
+	
+	// Migrate first so everything else sees the target version's attribute names
+	if sp.schema != nil {
+		sp.schema.migrateTraces(td)
+	}
+	droppedResourceAttrs := int64(0)
+	spansDropped := int64(0)
+	spansSkipped := int64(0)
+	var emptiedScopes map[scopeIndex]bool
+	batch := &batchState{}
+	
+	// Index the batch first so rules can read attributes of a span's parent
+	if sp.config.SpanProcessing.Enabled && sp.config.SpanProcessing.IndexParents {
+		ctx = withParentIndex(ctx, buildParentIndex(td))
+	}
 
 	// Process traces
 	resourceSpans := td.ResourceSpans()
@@ -132,38 +533,99 @@ func (sp *semconvProcessor) processTraces(ctx context.Context, td ptrace.Traces)
 		rs := resourceSpans.At(i)
 		resource := rs.Resource()
 		
+		// Classify first so rules can read the label
+		sp.classifyResource(ctx, resource)
+		
 		scopeSpans := rs.ScopeSpans()
 		for j := 0; j < scopeSpans.Len(); j++ {
 			ss := scopeSpans.At(j)
 			scope := ss.Scope()
 			spans := ss.Spans()
 			
-			for k := 0; k < spans.Len(); k++ {
-				span := spans.At(k)
+			// Scopes are shared by their spans, so they are rewritten once up front
+			if sp.config.SpanProcessing.Enabled {
+				sp.rewriteScopeName(ctx, scope)
+			}
+			
+			droppedBefore := spansDropped
+			spans.RemoveIf(func(span ptrace.Span) bool {
+				// Dropped spans are removed before any other work is spent on them, and
+				// regardless of the gates below, which only decide what gets rewritten
+				if sp.shouldDropSpan(ctx, span, resource, scope) {
+					spansDropped++
+					return true
+				}
+				
+				// Spans beyond the cap are neither processed nor counted
+				if limit := sp.config.SpanProcessing.MaxSpansPerBatch; limit > 0 && spanCount >= limit {
+					spansSkipped++
+					return false
+				}
 				spanCount++
+				process := sp.config.SpanProcessing.Enabled && sp.shouldProcessSpan(span)
+				
+				// Count before processing so attributes written by the rules are not included
+				if sp.reportUnknownAttrs {
+					sp.countUnknownAttributes(span, batch)
+				}
 				
 				// Process span if rules are enabled
-				if sp.config.SpanProcessing.Enabled {
-					sp.processSpan(ctx, span, resource, scope)
+				if process {
+					kindChanged := sp.applySpanKindRules(ctx, span, resource, scope)
+					
+					modified := sp.processSpan(ctx, span, resource, scope, batch) || kindChanged
+					if sp.config.SpanProcessing.MappingPhase == MappingPhaseAfter {
+						modified = sp.migrateAttributes(span) || modified
+					}
+					if sp.config.SpanProcessing.StampProvenance && modified {
+						stampProvenance(span, sp.version)
+					}
+					sp.trackOperationType(span, batch)
 				}
+				return false
+			})
+			
+			// Remember scopes emptied by drops; scopes that arrived empty are kept
+			if spansDropped > droppedBefore && spans.Len() == 0 {
+				if emptiedScopes == nil {
+					emptiedScopes = make(map[scopeIndex]bool)
+				}
+				emptiedScopes[scopeIndex{resource: i, scope: j}] = true
 			}
 		}
+		
+		// Filter last so rules and benchmark keys still see the full resource
+		droppedResourceAttrs += sp.filterResourceAttributes(resource)
+	}
+	sp.recordResourceAttributesDropped(ctx, "traces", droppedResourceAttrs)
+	sp.recordUnknownAttributes(ctx, batch)
+	
+	if spansDropped > 0 {
+		removeEmptySpanContainers(td, emptiedScopes)
+		sp.telemetry.ProcessorSemconvSpansDropped.Add(ctx, spansDropped)
+	}
+	if spansSkipped > 0 {
+		sp.telemetry.ProcessorSemconvSpansSkippedOverLimit.Add(ctx, spansSkipped)
 	}
 
+	// Empty batches did no work, so they would only add zeros to the latency stats
+	if spanCount == 0 {
+		return td, nil
+	}
+	
 	// Record metrics
-	if spanCount > 0 {
-		sp.telemetry.ProcessorSemconvSpansProcessed.Add(ctx, int64(spanCount), 
-			metric.WithAttributes(attribute.String("signal_type", "traces")))
+	sp.telemetry.ProcessorSemconvSpansProcessed.Add(ctx, int64(spanCount), 
+		metric.WithAttributes(attribute.String("signal_type", "traces")))
+	if sp.config.SpanProcessing.Enabled {
+		sp.telemetry.ProcessorSemconvDistinctOperationTypes.Record(ctx, int64(len(batch.operationTypes)))
 	}
 	
-	// Record benchmark metrics if enabled
-	if sp.config.Benchmark {
+	// Record benchmark metrics if enabled and not flushed periodically
+	if sp.config.Benchmark && sp.config.BenchmarkInterval <= 0 {
 		sp.recordBenchmarkMetrics(ctx)
 	}
 	
-	duration := float64(time.Since(start).Microseconds()) / 1000.0 // Convert to milliseconds
-	sp.telemetry.ProcessorSemconvProcessingDuration.Record(ctx, duration,
-		metric.WithAttributes(attribute.String("signal_type", "traces")))
+	sp.recordProcessingDuration(ctx, "traces", start)
 
 	return td, nil
 }
@@ -188,29 +650,90 @@ func getSpanKindString(kind ptrace.SpanKind) string {
 	}
 }
 
-// processSpan processes a single span according to configured rules
-func (sp *semconvProcessor) processSpan(ctx context.Context, span ptrace.Span, resource pcommon.Resource, scope pcommon.InstrumentationScope) {
+// processSpan processes a single span according to configured rules and reports whether
+// the span was modified
+func (sp *semconvProcessor) processSpan(ctx context.Context, span ptrace.Span, resource pcommon.Resource, scope pcommon.InstrumentationScope, batch *batchState) bool {
 	// Track original span name for benchmark mode
 	if sp.config.Benchmark {
+		sp.benchmarkMu.Lock()
 		if _, exists := sp.spanNameCount[span.Name()]; !exists {
 			// First time seeing this span name
 			sp.telemetry.ProcessorSemconvUniqueSpanNamesTotal.Add(ctx, 1)
 		}
 		sp.spanNameCount[span.Name()]++
+		sp.telemetry.ProcessorSemconvOriginalNameLength.Record(ctx, int64(utf8.RuneCountInString(span.Name())))
+		if sp.keyedSpanNameCount != nil {
+			incrementKeyed(sp.keyedSpanNameCount, sp.benchmarkKey(resource), span.Name())
+		}
+		sp.benchmarkMu.Unlock()
 	}
 	
-	// Check if operation.name is already set - if so, skip rule evaluation
-	if _, exists := span.Attributes().Get(sp.config.SpanProcessing.OperationNameAttribute); exists {
+	// Only audit the sampled fraction of traces
+	if sp.config.SpanProcessing.Mode == ModeAudit && !sp.auditSampled(span.TraceID()) {
+		return false
+	}
+	
+	// Promote a name stashed by an earlier processor before rules see the span
+	modified := false
+	if sp.config.SpanProcessing.UseAttributeAsName != "" && sp.config.SpanProcessing.Mode != ModeAudit {
+		if name, exists := span.Attributes().Get(sp.config.SpanProcessing.UseAttributeAsName); exists && name.AsString() != "" && name.AsString() != span.Name() {
+			span.SetName(name.AsString())
+			modified = true
+		}
+	}
+	
+	// Migrate attributes so rules only need the current names
+	if sp.config.SpanProcessing.MappingPhase != MappingPhaseAfter {
+		modified = sp.migrateAttributes(span) || modified
+	}
+	
+	// Normalize the HTTP method so rules only see semconv values
+	if sp.config.SpanProcessing.NormalizeHTTPMethod && sp.config.SpanProcessing.Mode != ModeAudit {
+		modified = normalizeSpanHTTPMethod(span) || modified
+	}
+	
+	// Normalize messaging destinations so names composed from them stay low-cardinality
+	if len(sp.destinationRewrites) > 0 && sp.config.SpanProcessing.Mode != ModeAudit {
+		modified = normalizeSpanDestination(ctx, span, sp.destinationRewrites, sp.regexes) || modified
+	}
+	
+	// Keep exception events compact and their types consistent
+	if sp.config.SpanProcessing.ExceptionEvents.enabled() && sp.config.SpanProcessing.Mode != ModeAudit {
+		modified = normalizeExceptionEvents(span, sp.config.SpanProcessing.ExceptionEvents) || modified
+	}
+	
+	// Write the path template for downstream aggregation without touching the name
+	if sp.config.SpanProcessing.EmitNormalizedPathAttribute != "" && sp.config.SpanProcessing.Mode != ModeAudit {
+		modified = emitNormalizedPath(span, sp.config.SpanProcessing.EmitNormalizedPathAttribute, sp.paths) || modified
+	}
+	
+	// Check if operation.name is already set - if so, skip rule evaluation unless
+	// an earlier stage's value should be replaced
+	if _, exists := span.Attributes().Get(sp.config.SpanProcessing.OperationNameAttribute); exists && !sp.config.SpanProcessing.ReprocessExisting {
 		// Operation name already set, skip processing
-		return
+		return modified
 	}
 	
+	// Exact name lookups are cheaper than rules, so check them first
+	if operationName, ok := sp.config.SpanProcessing.NameMappings[span.Name()]; ok {
+		return sp.applyOperation(ctx, span, resource, nameMappingsRuleID, operationName, "") || modified
+	}
+	
+	// Rule evaluation errors are attached to the span as events
+	errorEvents := batch.errorEvents
+	
 	// Create OTTL transform context - using dummy values for missing parameters
 	dummyScopeSpans := ptrace.NewScopeSpans()
 	dummyResourceSpans := ptrace.NewResourceSpans()
 	tCtx := ottlspan.NewTransformContext(span, scope, resource, dummyScopeSpans, dummyResourceSpans)
+	if sp.ruleMatches != nil {
+		sp.ruleWindowSpans.Add(1)
+	}
 	
-	// Evaluate rules in priority order
+	// Evaluate rules in priority order. With the "all" strategy every matching
+	// rule contributes and later rules override the name and type of earlier ones
+	var matchedRules []compiledRule
+	var ruleID, operationName, operationType string
 	for _, rule := range sp.compiledRules {
 		// Check span kind restriction if specified
 		if len(rule.SpanKind) > 0 {
@@ -233,6 +756,7 @@ func (sp *semconvProcessor) processSpan(ctx context.Context, span ptrace.Span, r
 			sp.logger.Debug("rule condition evaluation error",
 				zap.String("rule_id", rule.ID),
 				zap.Error(err))
+			sp.attachErrorEvent(span, batch, rule.ID, err)
 			continue
 		}
 		
@@ -241,91 +765,400 @@ func (sp *semconvProcessor) processSpan(ctx context.Context, span ptrace.Span, r
 		}
 		
 		// Rule matched - generate operation name
-		operationNameVal, err := rule.OperationName.Eval(ctx, tCtx)
+		name := sp.evalOperationName(ctx, tCtx, span, batch, rule)
+		
+		// A nil or blank name would leave the span without a usable name, so treat
+		// it as a non-match
+		if name == "" {
+			sp.logger.Debug("operation name is empty, skipping rule",
+				zap.String("rule_id", rule.ID))
+			continue
+		}
+		ruleID = rule.ID
+		operationName = name
+		
+		// Generate operation type if defined; a type differing from an earlier rule's is a conflict
+		if rule.OperationType != nil {
+			operationTypeVal, err := rule.OperationType.Eval(ctx, tCtx)
+			if err == nil {
+				ruleType := fmt.Sprintf("%v", operationTypeVal)
+				if operationType != "" && ruleType != operationType {
+					sp.telemetry.ProcessorSemconvTypeConflicts.Add(ctx, 1,
+						metric.WithAttributes(attribute.String("rule_id", rule.ID)))
+				}
+				if operationType == "" || sp.config.SpanProcessing.OperationTypePrecedence != OperationTypeFirstWins {
+					operationType = ruleType
+				}
+			} else {
+				sp.attachErrorEvent(span, batch, rule.ID, err)
+			}
+		}
+		matchedRules = append(matchedRules, rule)
+		sp.countRuleMatch(rule.ID)
+		
+		// First match wins - stop processing
+		if sp.config.SpanProcessing.MatchStrategy != MatchAll {
+			break
+		}
+	}
+	
+	if len(matchedRules) > 0 {
+		modified = sp.applyOperation(ctx, span, resource, ruleID, operationName, operationType) || modified
+		for _, rule := range matchedRules {
+			modified = sp.applyExtraAttributes(ctx, tCtx, span, batch, rule) || modified
+		}
+	}
+	return modified || batch.errorEvents != errorEvents
+}
+
+// writeOperationType writes the operation type, resolving a conflict with an existing
+// value according to the operation_type_conflict policy, reporting whether it was written
+func (sp *semconvProcessor) writeOperationType(span ptrace.Span, operationType string) bool {
+	if operationType == "" {
+		return false
+	}
+	
+	key := sp.config.SpanProcessing.OperationTypeAttribute
+	if existing, exists := span.Attributes().Get(key); exists {
+		switch sp.config.SpanProcessing.OperationTypeConflict {
+		case OperationTypeOverwrite:
+			// Replace the existing value
+		case OperationTypeKeepIfNonEmpty:
+			if existing.AsString() != "" {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	span.Attributes().PutStr(key, operationType)
+	return true
+}
+
+// writeRoutingClass writes the routing class mapped from the span's operation type;
+// unmapped types get no routing attribute
+func (sp *semconvProcessor) writeRoutingClass(span ptrace.Span) bool {
+	operationType, exists := span.Attributes().Get(sp.config.SpanProcessing.OperationTypeAttribute)
+	if !exists {
+		return false
+	}
+	
+	class, mapped := sp.config.SpanProcessing.RoutingClassMap[operationType.AsString()]
+	if mapped {
+		span.Attributes().PutStr(sp.config.SpanProcessing.RoutingClassAttribute, class)
+	}
+	return mapped
+}
+
+// applyExtraAttributes evaluates a matched rule's extra attribute expressions and writes
+// the results, reporting whether any attribute was written
+func (sp *semconvProcessor) applyExtraAttributes(ctx context.Context, tCtx ottlspan.TransformContext, span ptrace.Span, batch *batchState, rule compiledRule) bool {
+	// Audit mode never modifies spans
+	if len(rule.ExtraAttributes) == 0 || sp.config.SpanProcessing.Mode == ModeAudit {
+		return false
+	}
+	
+	modified := false
+	for _, extra := range rule.ExtraAttributes {
+		val, err := extra.Value.Eval(ctx, tCtx)
+		if err != nil {
+			sp.logger.Debug("extra attribute evaluation error",
+				zap.String("rule_id", rule.ID),
+				zap.String("attribute", extra.Name),
+				zap.Error(err))
+			sp.attachErrorEvent(span, batch, rule.ID, err)
+			continue
+		}
+		if val == nil {
+			continue
+		}
+		
+		putAttribute(span.Attributes(), extra.Name, val)
+		modified = true
+	}
+	return modified
+}
+
+// putAttribute writes an OTTL result, keeping its native type (including slices and
+// maps returned by converters) where possible and falling back to its string form
+func putAttribute(attrs pcommon.Map, name string, val any) {
+	switch v := val.(type) {
+	case pcommon.Slice:
+		v.CopyTo(attrs.PutEmptySlice(name))
+	case pcommon.Map:
+		v.CopyTo(attrs.PutEmptyMap(name))
+	case pcommon.Value:
+		v.CopyTo(attrs.PutEmpty(name))
+	case []string:
+		slice := attrs.PutEmptySlice(name)
+		slice.EnsureCapacity(len(v))
+		for _, item := range v {
+			slice.AppendEmpty().SetStr(item)
+		}
+	default:
+		if err := attrs.PutEmpty(name).FromRaw(val); err != nil {
+			attrs.PutStr(name, fmt.Sprintf("%v", val))
+		}
+	}
+}
+
+// normalizeSpanHTTPMethod rewrites http.request.method to its semconv form,
+// preserving the raw value in http.request.method_original when it changes
+func normalizeSpanHTTPMethod(span ptrace.Span) bool {
+	method, exists := span.Attributes().Get(httpRequestMethodAttribute)
+	if !exists || method.Type() != pcommon.ValueTypeStr {
+		return false
+	}
+	
+	raw := method.Str()
+	normalized := normalizeHTTPMethodString(raw)
+	if normalized == raw {
+		return false
+	}
+	
+	if _, exists := span.Attributes().Get(httpRequestMethodOriginalAttribute); !exists {
+		span.Attributes().PutStr(httpRequestMethodOriginalAttribute, raw)
+	}
+	span.Attributes().PutStr(httpRequestMethodAttribute, normalized)
+	return true
+}
+
+// normalizeSpanDestination rewrites the messaging destination attributes in place
+func normalizeSpanDestination(ctx context.Context, span ptrace.Span, rewrites []compiledRewrite, regexes *regexGuard) bool {
+	modified := false
+	for _, key := range messagingDestinationAttributes {
+		destination, exists := span.Attributes().Get(key)
+		if !exists || destination.Type() != pcommon.ValueTypeStr || !regexes.allow(ctx, destination.Str()) {
+			continue
+		}
+		
+		if normalized := applyRewrites(destination.Str(), rewrites); normalized != destination.Str() {
+			destination.SetStr(normalized)
+			modified = true
+		}
+	}
+	return modified
+}
+
+// emitNormalizedPath writes the normalized form of the span's path to the named attribute
+func emitNormalizedPath(span ptrace.Span, attribute string, paths *pathNormalizer) bool {
+	for _, key := range pathAttributes {
+		path, exists := span.Attributes().Get(key)
+		if !exists || path.Type() != pcommon.ValueTypeStr || path.Str() == "" {
+			continue
+		}
+		
+		normalized := paths.normalize(path.Str())
+		if existing, exists := span.Attributes().Get(attribute); exists && existing.Type() == pcommon.ValueTypeStr && existing.Str() == normalized {
+			return false
+		}
+		span.Attributes().PutStr(attribute, normalized)
+		return true
+	}
+	return false
+}
+
+// rewriteScopeName applies the scope rewrites to the instrumentation scope name
+func (sp *semconvProcessor) rewriteScopeName(ctx context.Context, scope pcommon.InstrumentationScope) {
+	if len(sp.scopeRewrites) == 0 || sp.config.SpanProcessing.Mode == ModeAudit || !sp.regexes.allow(ctx, scope.Name()) {
+		return
+	}
+	
+	if rewritten := applyRewrites(scope.Name(), sp.scopeRewrites); rewritten != scope.Name() {
+		scope.SetName(rewritten)
+	}
+}
+
+// auditSampled reports whether a trace falls into the audit sample. The decision
+// hashes the trace ID so every span of a trace, on every collector, agrees.
+func (sp *semconvProcessor) auditSampled(traceID pcommon.TraceID) bool {
+	ratio := sp.config.SpanProcessing.AuditSampleRatio
+	if ratio <= 0 || ratio >= 1 {
+		return true
+	}
+	
+	hash := fnv.New64a()
+	_, _ = hash.Write(traceID[:])
+	return float64(mix64(hash.Sum64())) < ratio*math.MaxUint64
+}
+
+// mix64 spreads FNV's weak high bits (murmur3 finalizer) so similar trace IDs
+// do not land in the same part of the range
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// evalOperationName renders the rule's template, or evaluates its operation name
+// candidates in order and returns the first non-blank result, or "" when every
+// candidate fails or is blank
+func (sp *semconvProcessor) evalOperationName(ctx context.Context, tCtx ottlspan.TransformContext, span ptrace.Span, batch *batchState, rule compiledRule) string {
+	if rule.Template != nil {
+		return rule.Template.render(span.Attributes())
+	}
+	
+	for _, candidate := range rule.OperationNames {
+		operationNameVal, err := candidate.Eval(ctx, tCtx)
 		if err != nil {
 			sp.logger.Debug("operation name generation error",
 				zap.String("rule_id", rule.ID),
 				zap.Error(err))
+			sp.attachErrorEvent(span, batch, rule.ID, err)
+			continue
+		}
+		if operationNameVal == nil {
 			continue
 		}
 		
 		// Convert to string
-		operationName := fmt.Sprintf("%v", operationNameVal)
-		
-		// Generate operation type if defined
-		var operationType string
-		if rule.OperationType != nil {
-			operationTypeVal, err := rule.OperationType.Eval(ctx, tCtx)
-			if err == nil {
-				operationType = fmt.Sprintf("%v", operationTypeVal)
-			}
+		name := fmt.Sprintf("%v", operationNameVal)
+		if strings.TrimSpace(name) != "" {
+			return name
 		}
+	}
+	return ""
+}
+
+// attachErrorEvent records a rule evaluation error on the span when error events are enabled
+func (sp *semconvProcessor) attachErrorEvent(span ptrace.Span, batch *batchState, ruleID string, err error) {
+	if !sp.config.SpanProcessing.AttachErrorEvents || batch.errorEvents >= maxErrorEventsPerBatch {
+		return
+	}
+	batch.errorEvents++
+	
+	event := span.Events().AppendEmpty()
+	event.SetName(errorEventName)
+	event.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	event.Attributes().PutStr("rule_id", ruleID)
+	event.Attributes().PutStr("error.message", err.Error())
+}
+
+// applyOperation writes a generated operation name and type to the span according to the
+// processing mode and reports whether the span was modified
+func (sp *semconvProcessor) applyOperation(ctx context.Context, span ptrace.Span, resource pcommon.Resource, ruleID, operationName, operationType string) bool {
+	originalName := span.Name()
+	modified := false
+	
+	// Fall back to the operation type implied by the span's attributes
+	if operationType == "" && sp.config.SpanProcessing.InferOperationType {
+		operationType, modified = sp.inferOperationType(span)
+	}
+	
+	// Apply based on mode
+	switch sp.config.SpanProcessing.Mode {
+	case ModeEnrich:
+		// A generic name carries no information, so it is replaced even in enrich mode
+		replaceGeneric := sp.genericName != nil && sp.regexes.allow(ctx, originalName) && sp.genericName.MatchString(originalName) && originalName != operationName
 		
-		// Apply based on mode
-		switch sp.config.SpanProcessing.Mode {
-		case ModeEnrich:
-			// Only add attributes
+		// Only add attributes, unless the operation name would merely repeat the span name
+		if !sp.config.SpanProcessing.SkipMatchingOperationName || (originalName != operationName && !replaceGeneric) {
 			span.Attributes().PutStr(sp.config.SpanProcessing.OperationNameAttribute, operationName)
-			if operationType != "" {
-				// Only set operation.type if not already present
-				if _, exists := span.Attributes().Get(sp.config.SpanProcessing.OperationTypeAttribute); !exists {
-					span.Attributes().PutStr(sp.config.SpanProcessing.OperationTypeAttribute, operationType)
-				}
+			modified = true
+		}
+		modified = sp.writeOperationType(span, operationType) || modified
+		
+		if replaceGeneric {
+			if sp.config.SpanProcessing.PreserveOriginalName {
+				span.Attributes().PutStr(sp.config.SpanProcessing.OriginalNameAttribute, originalName)
 			}
-			
-			// Record what would be enforced in enrich mode
-			sp.telemetry.ProcessorSemconvSpanNamesEnforced.Add(ctx, 1,
-				metric.WithAttributes(
-					attribute.String("rule_id", rule.ID),
-					attribute.String("operation_type", operationType),
-					attribute.String("mode", "enrich"),
-				))
-			
-		case ModeEnforce:
-			// Add operation name as attribute
+			span.SetName(operationName)
+			modified = true
+		}
+		
+		// Record what would be enforced in enrich mode
+		sp.telemetry.ProcessorSemconvSpanNamesEnforced.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("rule_id", ruleID),
+				attribute.String("operation_type", operationType),
+				attribute.String("mode", "enrich"),
+			))
+		
+	case ModeEnforce:
+		// A name that is already normalized is not rewritten or counted as enforced;
+		// only the operation type, which the name does not carry, is added
+		if sp.config.SpanProcessing.OnlyIfNormalized && span.Name() == operationName {
+			modified = sp.writeOperationType(span, operationType) || modified
+			break
+		}
+		
+		// Add operation name as attribute unless it is suppressed as a duplicate of the span name
+		if sp.config.SpanProcessing.writesOperationNameAttribute() {
 			span.Attributes().PutStr(sp.config.SpanProcessing.OperationNameAttribute, operationName)
-			
-			// Override span name
-			originalName := span.Name()
-			if sp.config.SpanProcessing.PreserveOriginalName && originalName != operationName {
+			modified = true
+		}
+		
+		// Override span name
+		if originalName != operationName {
+			if sp.config.SpanProcessing.PreserveOriginalName {
 				span.Attributes().PutStr(sp.config.SpanProcessing.OriginalNameAttribute, originalName)
 			}
 			span.SetName(operationName)
-			
-			// Add operation type as attribute
-			if operationType != "" {
-				// Only set operation.type if not already present
-				if _, exists := span.Attributes().Get(sp.config.SpanProcessing.OperationTypeAttribute); !exists {
-					span.Attributes().PutStr(sp.config.SpanProcessing.OperationTypeAttribute, operationType)
-				}
-			}
-			
-			// Record actual enforcement
-			sp.telemetry.ProcessorSemconvSpanNamesEnforced.Add(ctx, 1,
-				metric.WithAttributes(
-					attribute.String("rule_id", rule.ID),
-					attribute.String("operation_type", operationType),
-					attribute.String("mode", "enforce"),
-				))
-		}
-		
-		// Track operation name for benchmark mode
-		if sp.config.Benchmark {
-			if _, exists := sp.operationCount[operationName]; !exists {
-				// First time seeing this operation name
-				sp.telemetry.ProcessorSemconvUniqueOperationNamesTotal.Add(ctx, 1)
-			}
-			sp.operationCount[operationName]++
+			modified = true
 		}
 		
-		// First match wins - stop processing
-		break
+		// Add operation type as attribute
+		modified = sp.writeOperationType(span, operationType) || modified
+		
+		// Record actual enforcement
+		sp.telemetry.ProcessorSemconvSpanNamesEnforced.Add(ctx, 1,
+			metric.WithAttributes(
+				attribute.String("rule_id", ruleID),
+				attribute.String("operation_type", operationType),
+				attribute.String("mode", "enforce"),
+			))
+		
+	case ModeAudit:
+		// Never modify the name; only report when it differs from the generated one
+		if span.Name() != operationName {
+			sp.telemetry.ProcessorSemconvViolations.Add(ctx, 1,
+				metric.WithAttributes(attribute.String("rule_id", ruleID)))
+			if sp.config.SpanProcessing.MarkNonConformant {
+				span.Attributes().PutBool(conformantAttribute, false)
+				modified = true
+			}
+		}
+	}
+	
+	// Derive the routing hint from the operation type the span ended up with
+	if len(sp.config.SpanProcessing.RoutingClassMap) > 0 && sp.config.SpanProcessing.Mode != ModeAudit {
+		modified = sp.writeRoutingClass(span) || modified
 	}
+	
+	// Track operation name for benchmark mode
+	if sp.config.Benchmark {
+		sp.benchmarkMu.Lock()
+		if _, exists := sp.operationCount[operationName]; !exists {
+			// First time seeing this operation name
+			sp.telemetry.ProcessorSemconvUniqueOperationNamesTotal.Add(ctx, 1)
+		}
+		sp.operationCount[operationName]++
+		absorbed, exists := sp.ruleAbsorbedNames[ruleID]
+		if !exists {
+			absorbed = make(map[string]struct{})
+			sp.ruleAbsorbedNames[ruleID] = absorbed
+		}
+		if len(absorbed) < maxAbsorbedNamesPerRule {
+			absorbed[originalName] = struct{}{}
+		}
+		if sp.nameMappingCount != nil {
+			sp.nameMappingCount[nameMapping{original: originalName, operation: operationName}]++
+		}
+		sp.telemetry.ProcessorSemconvOperationNameLength.Record(ctx, int64(utf8.RuneCountInString(operationName)))
+		if sp.keyedOperationCount != nil {
+			incrementKeyed(sp.keyedOperationCount, sp.benchmarkKey(resource), operationName)
+		}
+		sp.benchmarkMu.Unlock()
+	}
+	return modified
 }
 
 // processMetrics processes the incoming metrics
 func (sp *semconvProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
-	if !sp.config.Enabled {
+	if !sp.config.Enabled || !sp.config.signalEnabled("metrics") {
 		return md, nil
 	}
 
@@ -334,50 +1167,237 @@ func (sp *semconvProcessor) processMetrics(ctx context.Context, md pmetric.Metri
 	// Process metrics here
 	// This is where you would implement semantic convention processing for metrics
 	// Currently, this processor focuses on span name enforcement for traces
+	if sp.schema != nil {
+		sp.schema.migrateMetrics(md)
+	}
+	
+	droppedResourceAttrs := int64(0)
+	resourceMetrics := md.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		sp.classifyResource(ctx, resourceMetrics.At(i).Resource())
+		droppedResourceAttrs += sp.filterResourceAttributes(resourceMetrics.At(i).Resource())
+	}
+	sp.recordResourceAttributesDropped(ctx, "metrics", droppedResourceAttrs)
 
-	duration := float64(time.Since(start).Microseconds()) / 1000.0 // Convert to milliseconds
-	sp.telemetry.ProcessorSemconvProcessingDuration.Record(ctx, duration,
-		metric.WithAttributes(attribute.String("signal_type", "metrics")))
+	sp.recordProcessingDuration(ctx, "metrics", start)
 
 	return md, nil
 }
 
 // processLogs processes the incoming logs
 func (sp *semconvProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
-	if !sp.config.Enabled {
+	if !sp.config.Enabled || !sp.config.signalEnabled("logs") {
 		return ld, nil
 	}
 
 	start := time.Now()
+	
+	if sp.schema != nil {
+		sp.schema.migrateLogs(ld)
+	}
 
-	// Process logs here
-	// This is where you would implement semantic convention processing for logs
+	droppedResourceAttrs := int64(0)
 	resourceLogs := ld.ResourceLogs()
 	for i := 0; i < resourceLogs.Len(); i++ {
 		rl := resourceLogs.At(i)
+		sp.classifyResource(ctx, rl.Resource())
 		
 		scopeLogs := rl.ScopeLogs()
 		for j := 0; j < scopeLogs.Len(); j++ {
 			sl := scopeLogs.At(j)
 			logs := sl.LogRecords()
 			for k := 0; k < logs.Len(); k++ {
-				// Process log records here
-				// This is where you would implement semantic convention processing for logs
+				if sp.config.LogProcessing.Enabled {
+					sp.processLogRecord(ctx, logs.At(k))
+				}
 			}
 		}
+		
+		droppedResourceAttrs += sp.filterResourceAttributes(rl.Resource())
+	}
+	sp.recordResourceAttributesDropped(ctx, "logs", droppedResourceAttrs)
+
+	sp.recordProcessingDuration(ctx, "logs", start)
+
+	return ld, nil
+}
+
+// processProfiles processes the incoming profiles
+func (sp *semconvProcessor) processProfiles(ctx context.Context, pd pprofile.Profiles) (pprofile.Profiles, error) {
+	if !sp.config.Enabled || !sp.config.signalEnabled("profiles") {
+		return pd, nil
 	}
 
+	start := time.Now()
+
+	// Only resource-level processing shared with the other signals applies to profiles
+	if sp.schema != nil {
+		sp.schema.migrateProfiles(pd)
+	}
+	droppedResourceAttrs := int64(0)
+	resourceProfiles := pd.ResourceProfiles()
+	for i := 0; i < resourceProfiles.Len(); i++ {
+		sp.classifyResource(ctx, resourceProfiles.At(i).Resource())
+		droppedResourceAttrs += sp.filterResourceAttributes(resourceProfiles.At(i).Resource())
+	}
+	sp.recordResourceAttributesDropped(ctx, "profiles", droppedResourceAttrs)
+
+	sp.recordProcessingDuration(ctx, "profiles", start)
+
+	return pd, nil
+}
+
+// classifyResource labels the resource when service_classification rules are configured
+func (sp *semconvProcessor) classifyResource(ctx context.Context, resource pcommon.Resource) {
+	if sp.config.ServiceClassification.enabled() {
+		sp.services.classifyResource(ctx, resource)
+	}
+}
+
+// filterResourceAttributes removes resource attributes that are not allowlisted and
+// returns how many were removed
+func (sp *semconvProcessor) filterResourceAttributes(resource pcommon.Resource) int64 {
+	if sp.allowedResourceAttrs == nil {
+		return 0
+	}
+	
+	var dropped int64
+	resource.Attributes().RemoveIf(func(key string, _ pcommon.Value) bool {
+		if sp.allowedResourceAttrs[key] {
+			return false
+		}
+		dropped++
+		return true
+	})
+	return dropped
+}
+
+// recordProcessingDuration records the time taken to process a batch. With a
+// metrics_sample_ratio only that fraction of each signal's batches is recorded.
+func (sp *semconvProcessor) recordProcessingDuration(ctx context.Context, signalType string, start time.Time) {
+	if ratio := sp.config.MetricsSampleRatio; ratio > 0 && ratio < 1 {
+		// Deterministic: record whenever the running total of sampled batches crosses an integer
+		n := sp.durationBatches[signalType].Add(1)
+		if math.Floor(float64(n)*ratio) == math.Floor(float64(n-1)*ratio) {
+			return
+		}
+	}
+	
 	duration := float64(time.Since(start).Microseconds()) / 1000.0 // Convert to milliseconds
 	sp.telemetry.ProcessorSemconvProcessingDuration.Record(ctx, duration,
-		metric.WithAttributes(attribute.String("signal_type", "logs")))
+		metric.WithAttributes(attribute.String("signal_type", signalType)))
+}
 
-	return ld, nil
+// recordResourceAttributesDropped reports resource attributes removed by the allowlist for a batch
+func (sp *semconvProcessor) recordResourceAttributesDropped(ctx context.Context, signalType string, dropped int64) {
+	if dropped == 0 {
+		return
+	}
+	sp.telemetry.ProcessorSemconvResourceAttributesDropped.Add(ctx, dropped,
+		metric.WithAttributes(attribute.String("signal_type", signalType)))
+}
+
+// migrateAttributes applies the attribute migrations, net.* to server.* and client.*
+// and synonym removal, in the phase chosen by mapping_phase (not applied in audit mode).
+// It reports whether any attribute was migrated.
+func (sp *semconvProcessor) migrateAttributes(span ptrace.Span) bool {
+	if sp.config.SpanProcessing.Mode == ModeAudit {
+		return false
+	}
+	
+	// Migrate deprecated net.* attributes
+	modified := false
+	if sp.config.SpanProcessing.NetToServerClient {
+		modified = migrateNetAttributes(span)
+		if sp.config.SpanProcessing.ProcessLinkAttributes {
+			modified = migrateLinkNetAttributes(span) || modified
+		}
+	}
+	
+	// Drop synonyms left behind by a gradual migration
+	if len(sp.config.SpanProcessing.AttributeSynonyms) > 0 {
+		modified = sp.dedupSynonyms(span.Attributes()) || modified
+	}
+	return modified
+}
+
+// trackOperationType adds the span's operation type, as written by the rules or an
+// earlier stage, to the distinct types of the batch
+func (sp *semconvProcessor) trackOperationType(span ptrace.Span, batch *batchState) {
+	operationType, exists := span.Attributes().Get(sp.config.SpanProcessing.OperationTypeAttribute)
+	if !exists || operationType.AsString() == "" {
+		return
+	}
+	if batch.operationTypes == nil {
+		batch.operationTypes = make(map[string]bool)
+	}
+	if len(batch.operationTypes) < maxDistinctOperationTypes {
+		batch.operationTypes[operationType.AsString()] = true
+	}
+}
+
+// countUnknownAttributes tallies span attribute keys that are not in the semconv registry
+func (sp *semconvProcessor) countUnknownAttributes(span ptrace.Span, batch *batchState) {
+	span.Attributes().Range(func(key string, _ pcommon.Value) bool {
+		if isKnownAttribute(key, sp.config.SemconvVersion) || sp.extraKnownAttrs[key] {
+			return true
+		}
+		if batch.unknownAttributes == nil {
+			batch.unknownAttributes = make(map[string]int64)
+		}
+		batch.unknownAttributes[key]++
+		return true
+	})
+}
+
+// recordUnknownAttributes reports the unknown attribute keys of a batch. Only the first
+// maxUnknownAttributeKeys distinct keys are reported by name to bound metric cardinality.
+func (sp *semconvProcessor) recordUnknownAttributes(ctx context.Context, batch *batchState) {
+	if len(batch.unknownAttributes) == 0 {
+		return
+	}
+	
+	// Sort so the keys admitted under the bound do not depend on map iteration order
+	keys := make([]string, 0, len(batch.unknownAttributes))
+	for key := range batch.unknownAttributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	
+	counts := make(map[string]int64, len(keys))
+	sp.unknownAttrMu.Lock()
+	for _, key := range keys {
+		reported := key
+		if !sp.unknownAttrKeys[key] {
+			if len(sp.unknownAttrKeys) < maxUnknownAttributeKeys {
+				sp.unknownAttrKeys[key] = true
+			} else {
+				reported = otherAttributeKey
+			}
+		}
+		counts[reported] += batch.unknownAttributes[key]
+	}
+	sp.unknownAttrMu.Unlock()
+	
+	for key, count := range counts {
+		sp.telemetry.ProcessorSemconvUnknownAttributes.Add(ctx, count,
+			metric.WithAttributes(attribute.String("attribute_key", key)))
+	}
 }
 
 // recordBenchmarkMetrics records cardinality reduction metrics when benchmark mode is enabled
 func (sp *semconvProcessor) recordBenchmarkMetrics(ctx context.Context) {
+	sp.recordRuleAbsorption(ctx)
+	
+	if sp.keyedSpanNameCount != nil {
+		sp.recordKeyedBenchmarkMetrics(ctx)
+		return
+	}
+	
+	sp.benchmarkMu.Lock()
 	originalCount := int64(len(sp.spanNameCount))
 	reducedCount := int64(len(sp.operationCount))
+	sp.benchmarkMu.Unlock()
 	
 	// Record unique counts (gauges)
 	sp.telemetry.ProcessorSemconvOriginalSpanNameCount.Record(ctx, originalCount)
@@ -393,4 +1413,56 @@ func (sp *semconvProcessor) recordBenchmarkMetrics(ctx context.Context) {
 			zap.Int64("operation_names", reducedCount),
 			zap.Float64("reduction_percentage", reduction))
 	}
-}
\ No newline at end of file
+}
+
+// recordRuleAbsorption records how many unique span names each rule has absorbed,
+// showing which rules contribute most to the cardinality reduction
+func (sp *semconvProcessor) recordRuleAbsorption(ctx context.Context) {
+	sp.benchmarkMu.Lock()
+	absorbed := make(map[string]int64, len(sp.ruleAbsorbedNames))
+	for ruleID, names := range sp.ruleAbsorbedNames {
+		absorbed[ruleID] = int64(len(names))
+	}
+	sp.benchmarkMu.Unlock()
+	
+	for ruleID, count := range absorbed {
+		sp.telemetry.ProcessorSemconvRuleCardinalityAbsorbed.Record(ctx, count,
+			metric.WithAttributes(attribute.String("rule_id", ruleID)))
+	}
+}
+
+// recordKeyedBenchmarkMetrics records cardinality gauges per benchmark key value
+func (sp *semconvProcessor) recordKeyedBenchmarkMetrics(ctx context.Context) {
+	sp.benchmarkMu.Lock()
+	originalCounts := make(map[string]int64, len(sp.keyedSpanNameCount))
+	reducedCounts := make(map[string]int64, len(sp.keyedSpanNameCount))
+	for key, names := range sp.keyedSpanNameCount {
+		originalCounts[key] = int64(len(names))
+		reducedCounts[key] = int64(len(sp.keyedOperationCount[key]))
+	}
+	sp.benchmarkMu.Unlock()
+	
+	for key, originalCount := range originalCounts {
+		attrs := metric.WithAttributes(attribute.String(sp.config.BenchmarkKeyAttribute, key))
+		sp.telemetry.ProcessorSemconvOriginalSpanNameCount.Record(ctx, originalCount, attrs)
+		sp.telemetry.ProcessorSemconvReducedSpanNameCount.Record(ctx, reducedCounts[key], attrs)
+	}
+}
+
+// benchmarkKey returns the value of the configured benchmark key attribute on the resource
+func (sp *semconvProcessor) benchmarkKey(resource pcommon.Resource) string {
+	if val, exists := resource.Attributes().Get(sp.config.BenchmarkKeyAttribute); exists {
+		return val.AsString()
+	}
+	return ""
+}
+
+// incrementKeyed increments the occurrence count of name within the given key
+func incrementKeyed(counts map[string]map[string]int64, key, name string) {
+	names, exists := counts[key]
+	if !exists {
+		names = make(map[string]int64)
+		counts[key] = names
+	}
+	names[name]++
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestProcessTraces_AttributeExistenceConditions(t *testing.T) {
+	tests := []struct {
+		name         string
+		condition    string
+		attributes   map[string]string
+		expectedName string
+	}{
+		{
+			name:         "exists matches any value",
+			condition:    `attributes["http.route"] != nil`,
+			attributes:   map[string]string{"http.route": "/users"},
+			expectedName: "matched",
+		},
+		{
+			name:         "exists does not match when absent",
+			condition:    `attributes["http.route"] != nil`,
+			attributes:   map[string]string{"url.path": "/users"},
+			expectedName: "original",
+		},
+		{
+			name:         "not exists matches when absent",
+			condition:    `attributes["http.route"] == nil`,
+			attributes:   map[string]string{"url.path": "/users"},
+			expectedName: "matched",
+		},
+		{
+			name:         "not exists does not match when present",
+			condition:    `attributes["http.route"] == nil`,
+			attributes:   map[string]string{"http.route": "/users"},
+			expectedName: "original",
+		},
+		{
+			name:         "not equals matches a different value",
+			condition:    `attributes["http.request.method"] != "GET"`,
+			attributes:   map[string]string{"http.request.method": "POST"},
+			expectedName: "matched",
+		},
+		{
+			name:         "not equals does not match the same value",
+			condition:    `attributes["http.request.method"] != "GET"`,
+			attributes:   map[string]string{"http.request.method": "GET"},
+			expectedName: "original",
+		},
+		{
+			name:         "exists combined with absence",
+			condition:    `attributes["db.system"] != nil and attributes["db.statement"] == nil`,
+			attributes:   map[string]string{"db.system": "redis"},
+			expectedName: "matched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Mode:    ModeEnforce,
+					Rules: []OTTLRule{
+						{
+							ID:            "condition",
+							Priority:      100,
+							Condition:     tt.condition,
+							OperationName: `"matched"`,
+						},
+					},
+				},
+			}
+			require.NoError(t, cfg.Validate())
+
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("original")
+			for k, v := range tt.attributes {
+				span.Attributes().PutStr(k, v)
+			}
+
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expectedName, resultSpan.Name())
+		})
+	}
+}
+
+func TestProcessTraces_NumericConditions(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "server_error",
+					Priority:      100,
+					Condition:     `attributes["http.response.status_code"] >= 500 and attributes["http.response.status_code"] < 600`,
+					OperationName: `"server_error"`,
+				},
+				{
+					ID:            "server_error_string",
+					Priority:      200,
+					Condition:     `Int(attributes["status"]) >= 500`,
+					OperationName: `"server_error_string"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+
+	// 500-range status code - should match
+	errorSpan := ss.Spans().AppendEmpty()
+	errorSpan.SetName("error")
+	errorSpan.Attributes().PutInt("http.response.status_code", 503)
+
+	// 200 status code - should not match
+	okSpan := ss.Spans().AppendEmpty()
+	okSpan.SetName("ok")
+	okSpan.Attributes().PutInt("http.response.status_code", 200)
+
+	// Numeric string - converted with Int() and matched
+	stringSpan := ss.Spans().AppendEmpty()
+	stringSpan.SetName("string")
+	stringSpan.Attributes().PutStr("status", "502")
+
+	// Non-numeric value - Int() yields nil so the comparison is false
+	nonNumericSpan := ss.Spans().AppendEmpty()
+	nonNumericSpan.SetName("non_numeric")
+	nonNumericSpan.Attributes().PutStr("status", "unavailable")
+	nonNumericSpan.Attributes().PutStr("http.response.status_code", "unavailable")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "server_error", resultSpans.At(0).Name())
+	assert.Equal(t, "ok", resultSpans.At(1).Name())
+	assert.Equal(t, "server_error_string", resultSpans.At(2).Name())
+	assert.Equal(t, "non_numeric", resultSpans.At(3).Name())
+}
+
+func TestProcessTraces_StatusConditions(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "error_status",
+					Priority:      100,
+					Condition:     `status.code == STATUS_CODE_ERROR`,
+					OperationName: `Concat([name, "failed"], " ")`,
+					OperationType: `"error"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+
+	// Error status - should match
+	errorSpan := ss.Spans().AppendEmpty()
+	errorSpan.SetName("charge")
+	errorSpan.Status().SetCode(ptrace.StatusCodeError)
+	errorSpan.Status().SetMessage("card declined")
+
+	// Ok status - should not match
+	okSpan := ss.Spans().AppendEmpty()
+	okSpan.SetName("refund")
+	okSpan.Status().SetCode(ptrace.StatusCodeOk)
+
+	// Unset status - should not match
+	unsetSpan := ss.Spans().AppendEmpty()
+	unsetSpan.SetName("lookup")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "charge failed", resultSpans.At(0).Name())
+	operationType, exists := resultSpans.At(0).Attributes().Get("operation.type")
+	require.True(t, exists)
+	assert.Equal(t, "error", operationType.Str())
+
+	assert.Equal(t, "refund", resultSpans.At(1).Name())
+	_, exists = resultSpans.At(1).Attributes().Get("operation.type")
+	assert.False(t, exists)
+	assert.Equal(t, "lookup", resultSpans.At(2).Name())
+}
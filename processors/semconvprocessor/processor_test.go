@@ -5,18 +5,45 @@ package semconvprocessor
 
 import (
 	"context"
+	"encoding/binary"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadatatest"
 )
 
+// newTestProcessor validates cfg and creates a processor whose telemetry is recorded,
+// so tests can assert on the metrics it emits
+func newTestProcessor(t *testing.T, cfg *Config) (*semconvProcessor, *componenttest.Telemetry) {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	return processor, tel
+}
+
 func TestProcessTraces_Disabled(t *testing.T) {
 	cfg := &Config{
 		Enabled: false,
@@ -136,6 +163,82 @@ func TestProcessTraces_EnforceMode(t *testing.T) {
 	assert.Equal(t, "http", val.AsString())
 }
 
+func TestProcessTraces_EnforceMode_WriteOperationNameAttribute(t *testing.T) {
+	enabled := true
+	disabled := false
+	
+	tests := []struct {
+		name          string
+		writeAttr     *bool
+		wantAttribute bool
+	}{
+		{
+			name:          "default keeps attribute",
+			writeAttr:     nil,
+			wantAttribute: true,
+		},
+		{
+			name:          "attribute kept",
+			writeAttr:     &enabled,
+			wantAttribute: true,
+		},
+		{
+			name:          "attribute suppressed",
+			writeAttr:     &disabled,
+			wantAttribute: false,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                     true,
+					Mode:                        ModeEnforce,
+					WriteOperationNameAttribute: tt.writeAttr,
+					Rules: []OTTLRule{
+						{
+							ID:            "http_route",
+							Priority:      100,
+							Condition:     `attributes["http.route"] != nil`,
+							OperationName: `Concat([attributes["http.method"], attributes["http.route"]], " ")`,
+							OperationType: `"http"`,
+						},
+					},
+				},
+			}
+			require.NoError(t, cfg.Validate())
+			
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+			
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("original_name")
+			span.Attributes().PutStr("http.method", "GET")
+			span.Attributes().PutStr("http.route", "/api/users")
+			
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			
+			resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			
+			// The span name is enforced either way
+			assert.Equal(t, "GET /api/users", resultSpan.Name())
+			
+			_, exists := resultSpan.Attributes().Get("operation.name")
+			assert.Equal(t, tt.wantAttribute, exists)
+			
+			// Operation type is unaffected by the option
+			val, exists := resultSpan.Attributes().Get("operation.type")
+			assert.True(t, exists)
+			assert.Equal(t, "http", val.AsString())
+		})
+	}
+}
+
 func TestProcessTraces_SpanKindMatching(t *testing.T) {
 	cfg := &Config{
 		Enabled: true,
@@ -537,4 +640,2621 @@ func TestParseSQL_Patterns(t *testing.T) {
 			assert.Equal(t, tt.expected, resultSpan.Name())
 		})
 	}
-}
\ No newline at end of file
+}
+func TestProcessTraces_NameMappings(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			NameMappings: map[string]string{
+				"HealthCheckHandler.handle": "GET /health",
+			},
+			Rules: []OTTLRule{
+				{
+					ID:            "fallback",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"from_rule"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	
+	// Exact hit - renamed from the lookup table, rules skipped
+	hitSpan := ss.Spans().AppendEmpty()
+	hitSpan.SetName("HealthCheckHandler.handle")
+	
+	// Miss - falls through to the rules
+	missSpan := ss.Spans().AppendEmpty()
+	missSpan.SetName("SomethingElse")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "GET /health", resultSpans.At(0).Name())
+	val, exists := resultSpans.At(0).Attributes().Get("operation.name")
+	assert.True(t, exists)
+	assert.Equal(t, "GET /health", val.Str())
+	
+	assert.Equal(t, "from_rule", resultSpans.At(1).Name())
+}
+
+func TestProcessTraces_AuditMode(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:           true,
+			Mode:              ModeAudit,
+			MarkNonConformant: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http_route",
+					Priority:      100,
+					Condition:     `attributes["http.method"] != nil and attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.method"], attributes["http.route"]], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	
+	// Conforming span - name already matches what the rule would enforce
+	conforming := ss.Spans().AppendEmpty()
+	conforming.SetName("GET /users/{id}")
+	conforming.Attributes().PutStr("http.method", "GET")
+	conforming.Attributes().PutStr("http.route", "/users/{id}")
+	
+	// Non-conforming span - raw path in the name
+	violating := ss.Spans().AppendEmpty()
+	violating.SetName("GET /users/123")
+	violating.Attributes().PutStr("http.method", "GET")
+	violating.Attributes().PutStr("http.route", "/users/{id}")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	// Names are never modified and no operation attributes are written
+	assert.Equal(t, "GET /users/{id}", resultSpans.At(0).Name())
+	assert.Equal(t, "GET /users/123", resultSpans.At(1).Name())
+	_, exists := resultSpans.At(1).Attributes().Get("operation.name")
+	assert.False(t, exists)
+	
+	// Only the violating span is tagged
+	_, exists = resultSpans.At(0).Attributes().Get("semconv.conformant")
+	assert.False(t, exists)
+	val, exists := resultSpans.At(1).Attributes().Get("semconv.conformant")
+	require.True(t, exists)
+	assert.False(t, val.Bool())
+	
+	metadatatest.AssertEqualProcessorSemconvViolations(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      1,
+				Attributes: attribute.NewSet(attribute.String("rule_id", "http_route")),
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_AuditModeDoesNotMutate(t *testing.T) {
+	// Audit mode is reported as non-mutating, so even with every pre-rule rewrite
+	// enabled the batch must come out unchanged
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:                true,
+			Mode:                   ModeAudit,
+			UseAttributeAsName:     "span.name.override",
+			NormalizeHTTPMethod:    true,
+			NetToServerClient:      true,
+			ProcessLinkAttributes:  true,
+			DestinationNormalizers: []KeyRewrite{{Pattern: `-partition-\d+$`}},
+			AttributeSynonyms:      []AttributeSynonyms{{Canonical: "http.request.method", Synonyms: []string{"http.method"}}},
+			Rules: []OTTLRule{
+				{
+					ID:              "http_route",
+					Priority:        100,
+					Condition:       `attributes["http.route"] != nil`,
+					OperationName:   `attributes["http.route"]`,
+					ExtraAttributes: map[string]string{"http.route.normalized": `NormalizePath(attributes["http.route"])`},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("get")
+	span.SetKind(ptrace.SpanKindClient)
+	span.Attributes().PutStr("span.name.override", "override")
+	span.Attributes().PutStr("http.request.method", "get")
+	span.Attributes().PutStr("http.method", "get")
+	span.Attributes().PutStr("net.peer.name", "api.example.com")
+	span.Attributes().PutStr("messaging.destination.name", "orders-partition-3")
+	span.Attributes().PutStr("http.route", "/users/{id}")
+	span.Links().AppendEmpty().Attributes().PutStr("net.sock.peer.addr", "10.0.0.1")
+	
+	expected := ptrace.NewTraces()
+	traces.CopyTo(expected)
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestProcessTraces_BenchmarkPerKey(t *testing.T) {
+	cfg := &Config{
+		Enabled:               true,
+		Benchmark:             true,
+		BenchmarkKeyAttribute: "service.name",
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["url.path"] != nil`,
+					OperationName: `NormalizePath(attributes["url.path"])`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	
+	// Service "users" - three distinct raw names collapsing to one operation
+	usersRS := traces.ResourceSpans().AppendEmpty()
+	usersRS.Resource().Attributes().PutStr("service.name", "users")
+	usersSpans := usersRS.ScopeSpans().AppendEmpty().Spans()
+	for _, path := range []string{"/users/1", "/users/2", "/users/3"} {
+		span := usersSpans.AppendEmpty()
+		span.SetName(path)
+		span.Attributes().PutStr("url.path", path)
+	}
+	
+	// Service "orders" - two distinct raw names collapsing to two operations
+	ordersRS := traces.ResourceSpans().AppendEmpty()
+	ordersRS.Resource().Attributes().PutStr("service.name", "orders")
+	ordersSpans := ordersRS.ScopeSpans().AppendEmpty().Spans()
+	for _, path := range []string{"/orders/1", "/orders"} {
+		span := ordersSpans.AppendEmpty()
+		span.SetName(path)
+		span.Attributes().PutStr("url.path", path)
+	}
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	assert.Len(t, processor.keyedSpanNameCount["users"], 3)
+	assert.Len(t, processor.keyedOperationCount["users"], 1)
+	assert.Len(t, processor.keyedSpanNameCount["orders"], 2)
+	assert.Len(t, processor.keyedOperationCount["orders"], 2)
+	
+	metadatatest.AssertEqualProcessorSemconvOriginalSpanNameCount(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 3, Attributes: attribute.NewSet(attribute.String("service.name", "users"))},
+			{Value: 2, Attributes: attribute.NewSet(attribute.String("service.name", "orders"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorSemconvReducedSpanNameCount(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 1, Attributes: attribute.NewSet(attribute.String("service.name", "users"))},
+			{Value: 2, Attributes: attribute.NewSet(attribute.String("service.name", "orders"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_RuleCardinalityAbsorbed(t *testing.T) {
+	cfg := &Config{
+		Enabled:   true,
+		Benchmark: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `attributes["http.route"]`,
+				},
+				{
+					ID:            "db",
+					Priority:      200,
+					Condition:     `attributes["db.system"] != nil`,
+					OperationName: `attributes["db.system"]`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"GET /users/1", "GET /users/2", "GET /users/1"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.Attributes().PutStr("http.route", "/users/{id}")
+	}
+	dbSpan := spans.AppendEmpty()
+	dbSpan.SetName("SELECT * FROM users")
+	dbSpan.Attributes().PutStr("db.system", "postgresql")
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// Repeated names are only counted once per rule
+	metadatatest.AssertEqualProcessorSemconvRuleCardinalityAbsorbed(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 2, Attributes: attribute.NewSet(attribute.String("rule_id", "http"))},
+			{Value: 1, Attributes: attribute.NewSet(attribute.String("rule_id", "db"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestNewSemconvProcessor_DisabledFunctions(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:           true,
+			DisabledFunctions: []string{"NormalizePath"},
+			Rules: []OTTLRule{
+				{
+					ID:            "sql",
+					Priority:      100,
+					Condition:     `attributes["db.statement"] != nil`,
+					OperationName: `ParseSQL(attributes["db.statement"])`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	// The disabled function is not registered with the parser
+	_, err = processor.parser.ParseValueExpression(`NormalizePath(attributes["url.path"])`)
+	assert.Error(t, err)
+	
+	// Other functions remain available
+	_, err = processor.parser.ParseValueExpression(`RemoveQueryParams(attributes["url.path"])`)
+	assert.NoError(t, err)
+}
+
+func TestProcessTraces_UseAttributeAsName(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:            true,
+			Mode:               ModeEnrich,
+			UseAttributeAsName: "intended.name",
+			Rules: []OTTLRule{
+				{
+					ID:            "orders",
+					Priority:      100,
+					Condition:     `name == "GET /orders"`,
+					OperationName: `"list orders"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	
+	// Attribute present - promoted to the span name before rules run
+	promoted := ss.Spans().AppendEmpty()
+	promoted.SetName("handler")
+	promoted.Attributes().PutStr("intended.name", "GET /orders")
+	
+	// Attribute absent - name left alone
+	untouched := ss.Spans().AppendEmpty()
+	untouched.SetName("handler")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	spans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	assert.Equal(t, "GET /orders", spans.At(0).Name())
+	val, exists := spans.At(0).Attributes().Get("operation.name")
+	assert.True(t, exists, "rules should see the promoted name")
+	assert.Equal(t, "list orders", val.Str())
+	
+	assert.Equal(t, "handler", spans.At(1).Name())
+	_, exists = spans.At(1).Attributes().Get("operation.name")
+	assert.False(t, exists)
+}
+
+func TestProcessTraces_AttachErrorEvents(t *testing.T) {
+	tests := []struct {
+		name              string
+		attachErrorEvents bool
+		spans             int
+		expectedEvents    int
+	}{
+		{
+			name:              "disabled",
+			attachErrorEvents: false,
+			spans:             1,
+			expectedEvents:    0,
+		},
+		{
+			name:              "enabled",
+			attachErrorEvents: true,
+			spans:             1,
+			expectedEvents:    1,
+		},
+		{
+			name:              "capped per batch",
+			attachErrorEvents: true,
+			spans:             maxErrorEventsPerBatch + 5,
+			expectedEvents:    maxErrorEventsPerBatch,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:           true,
+					Mode:              ModeEnforce,
+					AttachErrorEvents: tt.attachErrorEvents,
+					Rules: []OTTLRule{
+						{
+							ID:        "broken",
+							Priority:  100,
+							Condition: `true`,
+							// NormalizePath needs a string; an int attribute makes evaluation fail
+							OperationName: `NormalizePath(attributes["http.target"])`,
+						},
+					},
+				},
+			}
+			require.NoError(t, cfg.Validate())
+			
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+			
+			traces := ptrace.NewTraces()
+			ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+			for i := 0; i < tt.spans; i++ {
+				span := ss.Spans().AppendEmpty()
+				span.SetName("original")
+				span.Attributes().PutInt("http.target", 42)
+			}
+			
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			
+			events := 0
+			spans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+			for i := 0; i < spans.Len(); i++ {
+				span := spans.At(i)
+				assert.Equal(t, "original", span.Name())
+				for j := 0; j < span.Events().Len(); j++ {
+					event := span.Events().At(j)
+					assert.Equal(t, "semconv.error", event.Name())
+					ruleID, _ := event.Attributes().Get("rule_id")
+					assert.Equal(t, "broken", ruleID.Str())
+					message, _ := event.Attributes().Get("error.message")
+					assert.NotEmpty(t, message.Str())
+					events++
+				}
+			}
+			assert.Equal(t, tt.expectedEvents, events)
+		})
+	}
+}
+
+func TestProcessTraces_ExtraAttributes(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "http_client",
+					Priority:      100,
+					Condition:     `attributes["http.url"] != nil`,
+					OperationName: `Concat([attributes["http.method"], NormalizePath(RemoveQueryParams(attributes["http.url"]))], " ")`,
+					ExtraAttributes: map[string]string{
+						"http.route":     `NormalizePath(RemoveQueryParams(attributes["http.url"]))`,
+						"server.address": `ExtractHost(attributes["net.peer.authority"])`,
+						"server.port":    `ExtractPort(attributes["net.peer.authority"])`,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("HTTP GET")
+	span.Attributes().PutStr("http.method", "GET")
+	span.Attributes().PutStr("http.url", "/users/123?expand=true")
+	span.Attributes().PutStr("net.peer.authority", "api.example.com:8443")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "GET /users/{id}", resultSpan.Name())
+	
+	route, exists := resultSpan.Attributes().Get("http.route")
+	require.True(t, exists)
+	assert.Equal(t, "/users/{id}", route.Str())
+	
+	address, exists := resultSpan.Attributes().Get("server.address")
+	require.True(t, exists)
+	assert.Equal(t, "api.example.com", address.Str())
+	
+	// Native types are preserved
+	port, exists := resultSpan.Attributes().Get("server.port")
+	require.True(t, exists)
+	assert.Equal(t, pcommon.ValueTypeInt, port.Type())
+	assert.Equal(t, int64(8443), port.Int())
+}
+
+func TestProcessTraces_AuditSampleRatio(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:          true,
+			Mode:             ModeAudit,
+			AuditSampleRatio: 0.25,
+			Rules: []OTTLRule{
+				{
+					ID:            "fixed",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"canonical"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	const spanCount = 2000
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	sampled := 0
+	for i := 0; i < spanCount; i++ {
+		var traceID pcommon.TraceID
+		binary.BigEndian.PutUint64(traceID[8:], uint64(i+1))
+		span := ss.Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetName("raw")
+		
+		// The decision is deterministic for a trace ID
+		require.Equal(t, processor.auditSampled(traceID), processor.auditSampled(traceID))
+		if processor.auditSampled(traceID) {
+			sampled++
+		}
+	}
+	
+	// Roughly the configured fraction is evaluated
+	assert.InDelta(t, 0.25, float64(sampled)/spanCount, 0.05)
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// Nothing is mutated
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < resultSpans.Len(); i++ {
+		assert.Equal(t, "raw", resultSpans.At(i).Name())
+		assert.Equal(t, 0, resultSpans.At(i).Attributes().Len())
+	}
+	
+	// Only sampled spans were evaluated and reported
+	metadatatest.AssertEqualProcessorSemconvViolations(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      int64(sampled),
+				Attributes: attribute.NewSet(attribute.String("rule_id", "fixed")),
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestResourceAttributeAllowlist(t *testing.T) {
+	cfg := &Config{
+		Enabled:                    true,
+		ResourceAttributeAllowlist: []string{"service.name", "deployment.environment.name"},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	populate := func(resource pcommon.Resource) {
+		resource.Attributes().PutStr("service.name", "checkout")
+		resource.Attributes().PutStr("deployment.environment.name", "prod")
+		resource.Attributes().PutStr("host.name", "node-1")
+		resource.Attributes().PutStr("user.email", "jane@example.com")
+	}
+	assertFiltered := func(t *testing.T, resource pcommon.Resource) {
+		assert.Equal(t, map[string]any{
+			"service.name":                "checkout",
+			"deployment.environment.name": "prod",
+		}, resource.Attributes().AsRaw())
+	}
+	
+	traces := ptrace.NewTraces()
+	populate(traces.ResourceSpans().AppendEmpty().Resource())
+	traces, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assertFiltered(t, traces.ResourceSpans().At(0).Resource())
+	
+	logs := plog.NewLogs()
+	populate(logs.ResourceLogs().AppendEmpty().Resource())
+	logs, err = processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	assertFiltered(t, logs.ResourceLogs().At(0).Resource())
+	
+	metrics := pmetric.NewMetrics()
+	populate(metrics.ResourceMetrics().AppendEmpty().Resource())
+	metrics, err = processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+	assertFiltered(t, metrics.ResourceMetrics().At(0).Resource())
+	
+	metadatatest.AssertEqualProcessorSemconvResourceAttributesDropped(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 2, Attributes: attribute.NewSet(attribute.String("signal_type", "logs"))},
+			{Value: 2, Attributes: attribute.NewSet(attribute.String("signal_type", "metrics"))},
+			{Value: 2, Attributes: attribute.NewSet(attribute.String("signal_type", "traces"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestSignals_OnlyTraces(t *testing.T) {
+	cfg := &Config{
+		Enabled:                    true,
+		Signals:                    []string{"traces"},
+		ResourceAttributeAllowlist: []string{"service.name"},
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "test",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"normalized"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("host.name", "node-1")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("original")
+	traces, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	assert.Equal(t, "normalized", traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	_, exists := traces.ResourceSpans().At(0).Resource().Attributes().Get("host.name")
+	assert.False(t, exists)
+	
+	// Metrics and logs are not listed, so they pass through unchanged
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "node-1")
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("http.server.request.duration")
+	expectedMetrics := pmetric.NewMetrics()
+	metrics.CopyTo(expectedMetrics)
+	metrics, err = processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+	assert.Equal(t, expectedMetrics, metrics)
+	
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("host.name", "node-1")
+	expectedLogs := plog.NewLogs()
+	logs.CopyTo(expectedLogs)
+	logs, err = processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	assert.Equal(t, expectedLogs, logs)
+}
+
+func TestProcessTraces_UnknownAttributes(t *testing.T) {
+	cfg := &Config{
+		Enabled:         true,
+		Benchmark:       true,
+		KnownAttributes: []string{"app.tenant"},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 2; i++ {
+		span := spans.AppendEmpty()
+		span.SetName("GET /users")
+		span.Attributes().PutStr("http.request.method", "GET")
+		span.Attributes().PutStr("http.request.header.x-request-id", "abc")
+		span.Attributes().PutStr("app.tenant", "acme")
+		span.Attributes().PutStr("customer_id", "42")
+	}
+	spans.At(0).Attributes().PutStr("retries", "3")
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// Registry keys, template keys and known_attributes are not counted
+	metadatatest.AssertEqualProcessorSemconvUnknownAttributes(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 2, Attributes: attribute.NewSet(attribute.String("attribute_key", "customer_id"))},
+			{Value: 1, Attributes: attribute.NewSet(attribute.String("attribute_key", "retries"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_UnknownAttributesSemconvVersion(t *testing.T) {
+	cfg := &Config{
+		Enabled:        true,
+		Benchmark:      true,
+		SemconvVersion: "1.24",
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("SELECT users")
+	span.Attributes().PutStr("db.statement", "SELECT * FROM users")
+	span.Attributes().PutStr("db.query.text", "SELECT * FROM users")
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// db.query.text was only added in 1.26
+	metadatatest.AssertEqualProcessorSemconvUnknownAttributes(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 1, Attributes: attribute.NewSet(attribute.String("attribute_key", "db.query.text"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_UnknownAttributesProcessorOutput(t *testing.T) {
+	cfg := &Config{
+		Enabled:   true,
+		Benchmark: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:                true,
+			OperationNameAttribute: "acme.operation",
+			OperationTypeAttribute: "acme.operation_type",
+			NameMappings:           map[string]string{"raw": "canonical"},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	// Attributes left by an earlier pass of the processor, under the configured names
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("checkout")
+	span.Attributes().PutStr("acme.operation", "checkout")
+	span.Attributes().PutStr("acme.operation_type", "http")
+	span.Attributes().PutStr("name.original", "checkout")
+	span.Attributes().PutStr("operation.name", "checkout")
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// The default name is not written by this configuration, so it is unknown
+	metadatatest.AssertEqualProcessorSemconvUnknownAttributes(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{Value: 1, Attributes: attribute.NewSet(attribute.String("attribute_key", "operation.name"))},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_UnknownAttributesBounded(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeAudit,
+			Rules: []OTTLRule{
+				{
+					ID:            "test",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"test"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	for i := 0; i < maxUnknownAttributeKeys+5; i++ {
+		span.Attributes().PutStr(fmt.Sprintf("custom.%03d", i), "value")
+	}
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// Keys beyond the bound are folded into a single series
+	expected := make([]metricdata.DataPoint[int64], 0, maxUnknownAttributeKeys+1)
+	for i := 0; i < maxUnknownAttributeKeys; i++ {
+		expected = append(expected, metricdata.DataPoint[int64]{
+			Value:      1,
+			Attributes: attribute.NewSet(attribute.String("attribute_key", fmt.Sprintf("custom.%03d", i))),
+		})
+	}
+	expected = append(expected, metricdata.DataPoint[int64]{
+		Value:      5,
+		Attributes: attribute.NewSet(attribute.String("attribute_key", otherAttributeKey)),
+	})
+	metadatatest.AssertEqualProcessorSemconvUnknownAttributes(t, tel, expected, metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_DatabaseCollectionPrecedence(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "db_statement",
+					Priority:      300,
+					SpanKind:      []string{"client"},
+					Condition:     `attributes["db.query.text"] != nil`,
+					OperationName: `ParseSQL(attributes["db.query.text"])`,
+				},
+				{
+					ID:            "db_collection",
+					Priority:      100,
+					SpanKind:      []string{"client"},
+					Condition:     `attributes["db.operation.name"] != nil and attributes["db.collection.name"] != nil`,
+					OperationName: `Concat([attributes["db.operation.name"], attributes["db.collection.name"]], " ")`,
+				},
+				{
+					ID:            "db_query_summary",
+					Priority:      200,
+					SpanKind:      []string{"client"},
+					Condition:     `attributes["db.query.summary"] != nil`,
+					OperationName: `attributes["db.query.summary"]`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	tests := []struct {
+		name         string
+		attributes   map[string]any
+		expectedName string
+	}{
+		{
+			name: "collection preferred over summary",
+			attributes: map[string]any{
+				"db.operation.name":  "find",
+				"db.collection.name": "users",
+				"db.query.summary":   "FIND users_by_email",
+			},
+			expectedName: "find users",
+		},
+		{
+			name: "falls back to summary without collection",
+			attributes: map[string]any{
+				"db.operation.name": "SELECT",
+				"db.query.summary":  "SELECT orders",
+				"db.query.text":     "SELECT * FROM orders o JOIN users u ON o.user_id = u.id",
+			},
+			expectedName: "SELECT orders",
+		},
+		{
+			name: "falls back to statement",
+			attributes: map[string]any{
+				"db.query.text": "DELETE FROM sessions WHERE expires_at < ?",
+			},
+			expectedName: "DELETE sessions",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("db")
+			span.SetKind(ptrace.SpanKindClient)
+			require.NoError(t, span.Attributes().FromRaw(tt.attributes))
+			
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			
+			assert.Equal(t, tt.expectedName, result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+		})
+	}
+}
+
+func TestProcessTraces_BenchmarkNameLength(t *testing.T) {
+	cfg := &Config{
+		Enabled:   true,
+		Benchmark: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.method"] != nil`,
+					OperationName: `Concat([attributes["http.method"], NormalizePath(attributes["url.path"])], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for _, path := range []string{"/users/12345/profile", "/users/67890/profile"} {
+		span := ss.Spans().AppendEmpty()
+		span.SetName("GET " + path)
+		span.Attributes().PutStr("http.method", "GET")
+		span.Attributes().PutStr("url.path", path)
+	}
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	metadatatest.AssertEqualProcessorSemconvOriginalNameLength(t, tel,
+		[]metricdata.HistogramDataPoint[int64]{
+			{
+				Bounds:       []float64{8, 16, 32, 64, 128, 256},
+				BucketCounts: []uint64{0, 0, 2, 0, 0, 0, 0},
+				Count:        2,
+				Sum:          48, // "GET /users/12345/profile" twice
+				Min:          metricdata.NewExtrema(int64(24)),
+				Max:          metricdata.NewExtrema(int64(24)),
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorSemconvOperationNameLength(t, tel,
+		[]metricdata.HistogramDataPoint[int64]{
+			{
+				Bounds:       []float64{8, 16, 32, 64, 128, 256},
+				BucketCounts: []uint64{0, 0, 2, 0, 0, 0, 0},
+				Count:        2,
+				Sum:          46, // "GET /users/{id}/profile" twice
+				Min:          metricdata.NewExtrema(int64(23)),
+				Max:          metricdata.NewExtrema(int64(23)),
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_ExtraAttributesStableOrder(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "layered",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"op"`,
+					// Each attribute reads the one before it in sorted order
+					ExtraAttributes: map[string]string{
+						"d.final":  `Concat([attributes["c.third"], "d"], "")`,
+						"a.first":  `"a"`,
+						"c.third":  `Concat([attributes["b.second"], "c"], "")`,
+						"b.second": `Concat([attributes["a.first"], "b"], "")`,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	// Map iteration order varies between runs; the output must not
+	for i := 0; i < 20; i++ {
+		telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+		processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+		require.NoError(t, err)
+		
+		traces := ptrace.NewTraces()
+		traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+		
+		result, err := processor.processTraces(context.Background(), traces)
+		require.NoError(t, err)
+		
+		val, exists := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("d.final")
+		require.True(t, exists)
+		require.Equal(t, "abcd", val.Str())
+	}
+}
+
+func TestProcessTraces_ExtraAttributesSlice(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "accept",
+					Priority:      100,
+					Condition:     `attributes["accept"] != nil`,
+					OperationName: `"GET /"`,
+					ExtraAttributes: map[string]string{
+						"http.request.header.accept": `SplitString(attributes["accept"], ",")`,
+						"tags":                       `Split(attributes["tags"], ";")`,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /")
+	span.Attributes().PutStr("accept", "text/html,application/json,*/*")
+	span.Attributes().PutStr("tags", "a;b")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	attrs := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	
+	header, exists := attrs.Get("http.request.header.accept")
+	require.True(t, exists)
+	require.Equal(t, pcommon.ValueTypeSlice, header.Type())
+	assert.Equal(t, []any{"text/html", "application/json", "*/*"}, header.Slice().AsRaw())
+	
+	// Standard converters returning []string are written as slices too
+	tags, exists := attrs.Get("tags")
+	require.True(t, exists)
+	require.Equal(t, pcommon.ValueTypeSlice, tags.Type())
+	assert.Equal(t, []any{"a", "b"}, tags.Slice().AsRaw())
+}
+
+func TestProcessTraces_MatchStrategy(t *testing.T) {
+	rules := []OTTLRule{
+		{
+			ID:              "generic_http",
+			Priority:        100,
+			Condition:       `attributes["http.method"] != nil`,
+			OperationName:   `Concat([attributes["http.method"], "request"], " ")`,
+			OperationType:   `"http"`,
+			ExtraAttributes: map[string]string{"layer": `"generic"`, "http.kind": `"generic"`},
+		},
+		{
+			ID:              "specific_route",
+			Priority:        200,
+			Condition:       `attributes["http.route"] != nil`,
+			OperationName:   `Concat([attributes["http.method"], attributes["http.route"]], " ")`,
+			ExtraAttributes: map[string]string{"layer": `"specific"`},
+		},
+	}
+	
+	tests := []struct {
+		name          string
+		strategy      MatchStrategy
+		expectedName  string
+		expectedLayer string
+		expectedKind  string
+	}{
+		{
+			name:          "first match wins",
+			strategy:      MatchFirst,
+			expectedName:  "GET request",
+			expectedLayer: "generic",
+			expectedKind:  "generic",
+		},
+		{
+			name:          "all matches contribute",
+			strategy:      MatchAll,
+			expectedName:  "GET /users",
+			expectedLayer: "specific",
+			expectedKind:  "generic",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:              true,
+					Mode:                 ModeEnforce,
+					PreserveOriginalName: true,
+					MatchStrategy:        tt.strategy,
+					Rules:                rules,
+				},
+			}
+			require.NoError(t, cfg.Validate())
+			
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+			
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("original")
+			span.Attributes().PutStr("http.method", "GET")
+			span.Attributes().PutStr("http.route", "/users")
+			
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			
+			resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expectedName, resultSpan.Name())
+			
+			// The original name is the incoming one, not an intermediate rule result
+			original, _ := resultSpan.Attributes().Get("name.original")
+			assert.Equal(t, "original", original.Str())
+			
+			// The type from the earlier rule survives when the later rule sets none
+			operationType, _ := resultSpan.Attributes().Get("operation.type")
+			assert.Equal(t, "http", operationType.Str())
+			
+			layer, _ := resultSpan.Attributes().Get("layer")
+			assert.Equal(t, tt.expectedLayer, layer.Str())
+			kind, _ := resultSpan.Attributes().Get("http.kind")
+			assert.Equal(t, tt.expectedKind, kind.Str())
+		})
+	}
+}
+
+func TestProcessTraces_OperationTypePrecedence(t *testing.T) {
+	rules := []OTTLRule{
+		{
+			ID:            "rpc",
+			Priority:      100,
+			Condition:     `attributes["rpc.system"] != nil`,
+			OperationName: `attributes["rpc.method"]`,
+			OperationType: `"rpc"`,
+		},
+		{
+			ID:            "http",
+			Priority:      200,
+			Condition:     `attributes["http.request.method"] != nil`,
+			OperationName: `Concat([attributes["http.request.method"], attributes["rpc.method"]], " ")`,
+			OperationType: `"http"`,
+		},
+		{
+			ID:            "untyped",
+			Priority:      300,
+			Condition:     `attributes["rpc.method"] != nil`,
+			OperationName: `attributes["rpc.method"]`,
+		},
+	}
+	
+	tests := []struct {
+		name         string
+		precedence   OperationTypePrecedence
+		expectedType string
+	}{
+		{
+			name:         "last wins by default",
+			expectedType: "http",
+		},
+		{
+			name:         "last wins",
+			precedence:   OperationTypeLastWins,
+			expectedType: "http",
+		},
+		{
+			name:         "first wins",
+			precedence:   OperationTypeFirstWins,
+			expectedType: "rpc",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:                 true,
+					Mode:                    ModeEnrich,
+					MatchStrategy:           MatchAll,
+					OperationTypePrecedence: tt.precedence,
+					Rules:                   rules,
+				},
+			}
+			require.NoError(t, cfg.Validate())
+			
+			tel := componenttest.NewTelemetry()
+			t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+			telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+			require.NoError(t, err)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+			require.NoError(t, err)
+			
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("call")
+			span.Attributes().PutStr("rpc.system", "grpc")
+			span.Attributes().PutStr("rpc.method", "GetUser")
+			span.Attributes().PutStr("http.request.method", "POST")
+			
+			_, err = processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			
+			operationType, _ := span.Attributes().Get("operation.type")
+			assert.Equal(t, tt.expectedType, operationType.Str())
+			
+			// The untyped rule does not conflict; only the differing type is counted
+			metadatatest.AssertEqualProcessorSemconvTypeConflicts(t, tel,
+				[]metricdata.DataPoint[int64]{
+					{
+						Value:      1,
+						Attributes: attribute.NewSet(attribute.String("rule_id", "http")),
+					},
+				}, metricdatatest.IgnoreTimestamp())
+		})
+	}
+}
+
+func TestProcessTraces_BlankOperationName(t *testing.T) {
+	tests := []struct {
+		name          string
+		operationName string
+	}{
+		{
+			name:          "whitespace",
+			operationName: `Concat(["  ", " "], "")`,
+		},
+		{
+			name:          "empty string",
+			operationName: `""`,
+		},
+		{
+			name:          "nil",
+			operationName: `attributes["missing"]`,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled: true,
+					Mode:    ModeEnforce,
+					Rules: []OTTLRule{
+						{
+							ID:            "blank",
+							Priority:      100,
+							Condition:     `true`,
+							OperationName: tt.operationName,
+						},
+					},
+				},
+			}
+			require.NoError(t, cfg.Validate())
+			
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+			
+			traces := ptrace.NewTraces()
+			traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("original")
+			
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			
+			// The rule is treated as a non-match, so the original name survives
+			resultSpan := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, "original", resultSpan.Name())
+			_, exists := resultSpan.Attributes().Get("operation.name")
+			assert.False(t, exists)
+		})
+	}
+}
+
+func TestProcessTraces_DurationCondition(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "slow_requests",
+					Priority:      100,
+					Condition:     `(end_time_unix_nano - start_time_unix_nano) > 1000000000`,
+					OperationName: `"slow request"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	start := time.Unix(1700000000, 0)
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	
+	slowSpan := spans.AppendEmpty()
+	slowSpan.SetName("GET /report")
+	slowSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	slowSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(2 * time.Second)))
+	
+	fastSpan := spans.AppendEmpty()
+	fastSpan.SetName("GET /health")
+	fastSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	fastSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(5 * time.Millisecond)))
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "slow request", resultSpans.At(0).Name())
+	assert.Equal(t, "GET /health", resultSpans.At(1).Name())
+}
+
+func TestProcessTraces_OnlySampled(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:     true,
+			Mode:        ModeEnforce,
+			OnlySampled: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `attributes["http.route"]`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	
+	sampledSpan := spans.AppendEmpty()
+	sampledSpan.SetName("GET /users/123")
+	sampledSpan.SetFlags(sampledTraceFlag)
+	sampledSpan.Attributes().PutStr("http.route", "/users/{id}")
+	
+	unsampledSpan := spans.AppendEmpty()
+	unsampledSpan.SetName("GET /users/456")
+	unsampledSpan.Attributes().PutStr("http.route", "/users/{id}")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "/users/{id}", resultSpans.At(0).Name())
+	
+	// The unsampled span is left untouched
+	assert.Equal(t, "GET /users/456", resultSpans.At(1).Name())
+	_, exists := resultSpans.At(1).Attributes().Get("operation.name")
+	assert.False(t, exists)
+}
+
+func TestProcessTraces_OnlyIfNormalized(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:              true,
+			Mode:                 ModeEnforce,
+			OnlyIfNormalized:     true,
+			PreserveOriginalName: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http_paths",
+					Priority:      100,
+					Condition:     `attributes["url.path"] != nil`,
+					OperationName: `Concat(["GET", NormalizePath(attributes["url.path"])], " ")`,
+					OperationType: `"http"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	
+	normalizedSpan := spans.AppendEmpty()
+	normalizedSpan.SetName("GET /users")
+	normalizedSpan.Attributes().PutStr("url.path", "/users")
+	
+	rawSpan := spans.AppendEmpty()
+	rawSpan.SetName("GET /users/123")
+	rawSpan.Attributes().PutStr("url.path", "/users/123")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	// The already normalized span only gains the operation type
+	assert.Equal(t, map[string]any{
+		"url.path":       "/users",
+		"operation.type": "http",
+	}, resultSpans.At(0).Attributes().AsRaw())
+	
+	assert.Equal(t, "GET /users/{id}", resultSpans.At(1).Name())
+	originalName, _ := resultSpans.At(1).Attributes().Get("name.original")
+	assert.Equal(t, "GET /users/123", originalName.Str())
+	
+	// Only the rewritten span counts as enforced
+	metadatatest.AssertEqualProcessorSemconvSpanNamesEnforced(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value: 1,
+				Attributes: attribute.NewSet(
+					attribute.String("rule_id", "http_paths"),
+					attribute.String("operation_type", "http"),
+					attribute.String("mode", "enforce"),
+				),
+			},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_OperationNameCandidates(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:           true,
+			Mode:              ModeEnforce,
+			AttachErrorEvents: true,
+			Rules: []OTTLRule{
+				{
+					ID:        "db",
+					Priority:  100,
+					Condition: `attributes["db.system"] != nil`,
+					OperationNameCandidates: []string{
+						`ParseSQL(attributes["db.statement"])`,
+						`attributes["db.query.summary"]`,
+						`attributes["db.operation.name"]`,
+					},
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	
+	// The first candidate errors because the statement is not a string
+	erroringSpan := spans.AppendEmpty()
+	erroringSpan.SetName("query")
+	erroringSpan.Attributes().PutStr("db.system", "postgresql")
+	erroringSpan.Attributes().PutInt("db.statement", 42)
+	erroringSpan.Attributes().PutStr("db.query.summary", "SELECT users")
+	
+	// The first two candidates return nil
+	nilSpan := spans.AppendEmpty()
+	nilSpan.SetName("query")
+	nilSpan.Attributes().PutStr("db.system", "redis")
+	nilSpan.Attributes().PutStr("db.operation.name", "GET")
+	
+	// No candidate yields a name, so the rule does not match
+	unmatchedSpan := spans.AppendEmpty()
+	unmatchedSpan.SetName("query")
+	unmatchedSpan.Attributes().PutStr("db.system", "redis")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "SELECT users", resultSpans.At(0).Name())
+	assert.Equal(t, 1, resultSpans.At(0).Events().Len())
+	assert.Equal(t, "GET", resultSpans.At(1).Name())
+	assert.Equal(t, "query", resultSpans.At(2).Name())
+}
+
+func TestProcessTraces_DestinationNormalizers(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			DestinationNormalizers: []KeyRewrite{
+				{Pattern: `-partition-\d+$`, Replacement: ""},
+			},
+			Rules: []OTTLRule{
+				{
+					ID:            "publish",
+					Priority:      100,
+					SpanKind:      []string{"producer"},
+					Condition:     `attributes["messaging.destination.name"] != nil`,
+					OperationName: `Concat(["publish", attributes["messaging.destination.name"]], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, destination := range []string{"orders-partition-3", "orders"} {
+		span := spans.AppendEmpty()
+		span.SetName("send")
+		span.SetKind(ptrace.SpanKindProducer)
+		span.Attributes().PutStr("messaging.destination.name", destination)
+	}
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// The partition suffix is stripped and the clean topic is left intact
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < resultSpans.Len(); i++ {
+		assert.Equal(t, "publish orders", resultSpans.At(i).Name())
+		destination, _ := resultSpans.At(i).Attributes().Get("messaging.destination.name")
+		assert.Equal(t, "orders", destination.Str())
+	}
+}
+
+func TestProcessTraces_PriorityWindow(t *testing.T) {
+	minPriority := 100
+	maxPriority := 499
+	
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:     true,
+			Mode:        ModeEnforce,
+			MinPriority: &minPriority,
+			MaxPriority: &maxPriority,
+			Rules: []OTTLRule{
+				{
+					ID:            "too_high",
+					Priority:      50,
+					Condition:     `attributes["kind"] == "high"`,
+					OperationName: `"high"`,
+				},
+				{
+					ID:            "in_window",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"in window"`,
+				},
+				{
+					ID:            "too_low",
+					Priority:      500,
+					Condition:     `true`,
+					OperationName: `"low"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	// Only the rule inside the window is compiled
+	require.Len(t, processor.compiledRules, 1)
+	assert.Equal(t, "in_window", processor.compiledRules[0].ID)
+	
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("original")
+	span.Attributes().PutStr("kind", "high")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// The higher priority rule would match but is switched off
+	assert.Equal(t, "in window", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestProcessTraces_DisabledRule(t *testing.T) {
+	disabled := false
+	enabled := true
+	
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "misbehaving",
+					Enabled:       &disabled,
+					Priority:      100,
+					Condition:     `attributes["kind"] == "high"`,
+					OperationName: `NoSuchFunction(attributes["kind"])`,
+				},
+				{
+					ID:            "explicitly_enabled",
+					Enabled:       &enabled,
+					Priority:      200,
+					Condition:     `attributes["kind"] == "high"`,
+					OperationName: `"enabled"`,
+				},
+				{
+					ID:            "default_enabled",
+					Priority:      300,
+					Condition:     `true`,
+					OperationName: `"fallback"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	// The disabled rule is not parsed, so its unknown function does not fail compilation
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	require.Len(t, processor.compiledRules, 2)
+	assert.Equal(t, "explicitly_enabled", processor.compiledRules[0].ID)
+	assert.Equal(t, "default_enabled", processor.compiledRules[1].ID)
+	
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("original")
+	span.Attributes().PutStr("kind", "high")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// The disabled rule would match first but is switched off
+	assert.Equal(t, "enabled", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestNewSemconvProcessor_RuleSummaryLog(t *testing.T) {
+	maxPriority := 200
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:     true,
+			Mode:        ModeEnforce,
+			MaxPriority: &maxPriority,
+			Rules: []OTTLRule{
+				{ID: "db", Priority: 200, Condition: `true`, OperationName: `"db"`},
+				{ID: "http", Priority: 100, Condition: `true`, OperationName: `"http"`},
+				{ID: "fallback", Priority: 1000, Condition: `true`, OperationName: `"fallback"`},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	core, logs := observer.New(zap.DebugLevel)
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	_, err := newSemconvProcessor(zap.New(core), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	summary := logs.FilterMessage("compiled span processing rules").All()
+	require.Len(t, summary, 1)
+	assert.Equal(t, zap.InfoLevel, summary[0].Level)
+	assert.Equal(t, int64(2), summary[0].ContextMap()["rules_compiled"])
+	assert.Equal(t, int64(1), summary[0].ContextMap()["rules_skipped"])
+	
+	// Compiled rules are listed in evaluation order at debug level
+	compiled := logs.FilterMessage("compiled rule").All()
+	require.Len(t, compiled, 2)
+	assert.Equal(t, zap.DebugLevel, compiled[0].Level)
+	assert.Equal(t, "http", compiled[0].ContextMap()["rule_id"])
+	assert.Equal(t, "db", compiled[1].ContextMap()["rule_id"])
+}
+
+func TestProcessTraces_ResourceCondition(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "checkout",
+					Priority:      100,
+					Condition:     `resource.attributes["service.name"] == "checkout"`,
+					OperationName: `Concat([resource.attributes["service.name"], name], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	for _, service := range []string{"checkout", "cart"} {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("pay")
+	}
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// Conditions see the span's real resource
+	assert.Equal(t, "checkout pay", result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, "pay", result.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestMetricsSampleRatio(t *testing.T) {
+	tests := []struct {
+		name              string
+		ratio             float64
+		expectedDurations uint64
+	}{
+		{name: "default records every batch", ratio: 0, expectedDurations: 8},
+		{name: "quarter of batches", ratio: 0.25, expectedDurations: 2},
+		{name: "half of batches", ratio: 0.5, expectedDurations: 4},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled:            true,
+				MetricsSampleRatio: tt.ratio,
+			}
+			require.NoError(t, cfg.Validate())
+			
+			tel := componenttest.NewTelemetry()
+			t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+			telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+			require.NoError(t, err)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+			require.NoError(t, err)
+			
+			for i := 0; i < 8; i++ {
+				traces := ptrace.NewTraces()
+				traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+				_, err = processor.processTraces(context.Background(), traces)
+				require.NoError(t, err)
+			}
+			
+			durations, err := tel.GetMetric("otelcol_processor_semconv_processing_duration")
+			require.NoError(t, err)
+			histogram := durations.Data.(metricdata.Histogram[float64])
+			require.Len(t, histogram.DataPoints, 1)
+			assert.Equal(t, tt.expectedDurations, histogram.DataPoints[0].Count)
+			
+			// The processed-span counter is not sampled
+			metadatatest.AssertEqualProcessorSemconvSpansProcessed(t, tel,
+				[]metricdata.DataPoint[int64]{
+					{Value: 8, Attributes: attribute.NewSet(attribute.String("signal_type", "traces"))},
+				},
+				metricdatatest.IgnoreTimestamp())
+		})
+	}
+}
+
+func TestProcessTraces_EmptyBatchRecordsNoMetrics(t *testing.T) {
+	cfg := &Config{
+		Enabled:   true,
+		Benchmark: true,
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	// Neither an empty traces object nor resources and scopes without spans count as work
+	_, err = processor.processTraces(context.Background(), ptrace.NewTraces())
+	require.NoError(t, err)
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	_, err = tel.GetMetric("otelcol_processor_semconv_processing_duration")
+	assert.Error(t, err)
+	_, err = tel.GetMetric("otelcol_processor_semconv_spans_processed")
+	assert.Error(t, err)
+	_, err = tel.GetMetric("otelcol_processor_semconv_original_span_name_count")
+	assert.Error(t, err)
+}
+
+func TestProcessTraces_EmitNormalizedPathAttribute(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:                     true,
+			Mode:                        ModeEnrich,
+			EmitNormalizedPathAttribute: "url.path.template",
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.request.method"] != nil`,
+					OperationName: `attributes["http.request.method"]`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	
+	current := spans.AppendEmpty()
+	current.SetName("GET /users/123")
+	current.Attributes().PutStr("http.request.method", "GET")
+	current.Attributes().PutStr("url.path", "/users/123")
+	
+	deprecated := spans.AppendEmpty()
+	deprecated.SetName("GET /orders/42?expand=true")
+	deprecated.Attributes().PutStr("http.request.method", "GET")
+	deprecated.Attributes().PutStr("http.target", "/orders/42?expand=true")
+	
+	noPath := spans.AppendEmpty()
+	noPath.SetName("GET")
+	noPath.Attributes().PutStr("http.request.method", "GET")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	// The template is written while enrich mode leaves the names alone
+	template, exists := resultSpans.At(0).Attributes().Get("url.path.template")
+	require.True(t, exists)
+	assert.Equal(t, "/users/{id}", template.Str())
+	assert.Equal(t, "GET /users/123", resultSpans.At(0).Name())
+	path, _ := resultSpans.At(0).Attributes().Get("url.path")
+	assert.Equal(t, "/users/123", path.Str())
+	
+	// http.target is used as a fallback and its query string is dropped
+	template, exists = resultSpans.At(1).Attributes().Get("url.path.template")
+	require.True(t, exists)
+	assert.Equal(t, "/orders/{id}", template.Str())
+	assert.Equal(t, "GET /orders/42?expand=true", resultSpans.At(1).Name())
+	
+	// Spans without a path get no template
+	_, exists = resultSpans.At(2).Attributes().Get("url.path.template")
+	assert.False(t, exists)
+}
+
+func TestProcessTraces_ReprocessExisting(t *testing.T) {
+	tests := []struct {
+		name              string
+		reprocessExisting bool
+		expected          string
+	}{
+		{name: "default preserves existing value", expected: "stale"},
+		{name: "reprocessing overwrites stale value", reprocessExisting: true, expected: "GET /users"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:           true,
+					Mode:              ModeEnrich,
+					ReprocessExisting: tt.reprocessExisting,
+					Rules: []OTTLRule{
+						{
+							ID:            "http",
+							Priority:      100,
+							Condition:     `attributes["http.route"] != nil`,
+							OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+						},
+					},
+				},
+			}
+			require.NoError(t, cfg.Validate())
+			
+			telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+			require.NoError(t, err)
+			
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("GET /users")
+			span.Attributes().PutStr("http.request.method", "GET")
+			span.Attributes().PutStr("http.route", "/users")
+			span.Attributes().PutStr("operation.name", "stale")
+			
+			result, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+			
+			operationName, _ := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("operation.name")
+			assert.Equal(t, tt.expected, operationName.Str())
+		})
+	}
+}
+
+func TestProcessTraces_OperationTypeConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   OperationTypeConflictPolicy
+		existing string
+		expected string
+	}{
+		{name: "default keeps existing", existing: "stale", expected: "stale"},
+		{name: "keep preserves existing", policy: OperationTypeKeep, existing: "stale", expected: "stale"},
+		{name: "keep preserves empty", policy: OperationTypeKeep, existing: "", expected: ""},
+		{name: "overwrite replaces existing", policy: OperationTypeOverwrite, existing: "stale", expected: "http"},
+		{name: "keep-if-nonempty preserves existing", policy: OperationTypeKeepIfNonEmpty, existing: "stale", expected: "stale"},
+		{name: "keep-if-nonempty replaces empty", policy: OperationTypeKeepIfNonEmpty, existing: "", expected: "http"},
+	}
+	
+	for _, mode := range []ProcessingMode{ModeEnrich, ModeEnforce} {
+		for _, tt := range tests {
+			t.Run(string(mode)+"/"+tt.name, func(t *testing.T) {
+				cfg := &Config{
+					Enabled: true,
+					SpanProcessing: SpanProcessingConfig{
+						Enabled:               true,
+						Mode:                  mode,
+						OperationTypeConflict: tt.policy,
+						Rules: []OTTLRule{
+							{
+								ID:            "http",
+								Priority:      100,
+								Condition:     `attributes["http.request.method"] != nil`,
+								OperationName: `attributes["http.request.method"]`,
+								OperationType: `"http"`,
+							},
+						},
+					},
+				}
+				require.NoError(t, cfg.Validate())
+				
+				telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+				processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+				require.NoError(t, err)
+				
+				traces := ptrace.NewTraces()
+				span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				span.SetName("request")
+				span.Attributes().PutStr("http.request.method", "GET")
+				span.Attributes().PutStr("operation.type", tt.existing)
+				
+				result, err := processor.processTraces(context.Background(), traces)
+				require.NoError(t, err)
+				
+				operationType, _ := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get("operation.type")
+				assert.Equal(t, tt.expected, operationType.Str())
+			})
+		}
+	}
+}
+
+func TestProcessTraces_PreserveOriginalNameUnchanged(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:              true,
+			Mode:                 ModeEnforce,
+			PreserveOriginalName: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"GET /users/{id}", "GET /users/123"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.Attributes().PutStr("http.request.method", "GET")
+		span.Attributes().PutStr("http.route", "/users/{id}")
+	}
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	// The name already matches, so neither an attribute nor an event records it
+	unchanged := resultSpans.At(0)
+	assert.Equal(t, "GET /users/{id}", unchanged.Name())
+	_, exists := unchanged.Attributes().Get("name.original")
+	assert.False(t, exists)
+	assert.Equal(t, 0, unchanged.Events().Len())
+	
+	// A renamed span keeps its original name
+	renamed := resultSpans.At(1)
+	assert.Equal(t, "GET /users/{id}", renamed.Name())
+	original, exists := renamed.Attributes().Get("name.original")
+	require.True(t, exists)
+	assert.Equal(t, "GET /users/123", original.Str())
+}
+
+func TestProcessTraces_ScopeRewrites(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			ScopeRewrites: []KeyRewrite{
+				{Pattern: `^my\.old\.lib$`, Replacement: "io.example.lib"},
+			},
+			Rules: []OTTLRule{
+				{
+					ID:            "scope",
+					Priority:      100,
+					Condition:     "true",
+					OperationName: `instrumentation_scope.name`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	for _, name := range []string{"my.old.lib", "my.old.library"} {
+		ss := rs.ScopeSpans().AppendEmpty()
+		ss.Scope().SetName(name)
+		ss.Scope().SetVersion("1.2.0")
+		ss.Spans().AppendEmpty().SetName("span")
+		ss.Spans().AppendEmpty().SetName("span")
+	}
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	scopeSpans := result.ResourceSpans().At(0).ScopeSpans()
+	
+	// The legacy scope is renamed before rules see it; the version is kept
+	rewritten := scopeSpans.At(0)
+	assert.Equal(t, "io.example.lib", rewritten.Scope().Name())
+	assert.Equal(t, "1.2.0", rewritten.Scope().Version())
+	for i := 0; i < rewritten.Spans().Len(); i++ {
+		operationName, _ := rewritten.Spans().At(i).Attributes().Get("operation.name")
+		assert.Equal(t, "io.example.lib", operationName.Str())
+	}
+	
+	// A scope that does not match is left alone
+	assert.Equal(t, "my.old.library", scopeSpans.At(1).Scope().Name())
+}
+
+func TestProcessTraces_RoutingClassMap(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			RoutingClassMap: map[string]string{
+				"http": "web",
+			},
+			Rules: []OTTLRule{
+				{
+					ID:            "typed",
+					Priority:      100,
+					Condition:     `attributes["type"] != nil`,
+					OperationName: `"operation"`,
+					OperationType: `attributes["type"]`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, operationType := range []string{"http", "batch"} {
+		span := spans.AppendEmpty()
+		span.SetName("span")
+		span.Attributes().PutStr("type", operationType)
+	}
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	class, exists := resultSpans.At(0).Attributes().Get("routing.class")
+	require.True(t, exists)
+	assert.Equal(t, "web", class.Str())
+	
+	// An unmapped type gets no routing attribute
+	_, exists = resultSpans.At(1).Attributes().Get("routing.class")
+	assert.False(t, exists)
+}
+
+func TestProcessTraces_SkipIfAttribute(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:         true,
+			Mode:            ModeEnforce,
+			SkipIfAttribute: AttributeMatch{Key: "sampling.keep", Value: "false"},
+			Rules: []OTTLRule{
+				{
+					ID:            "all",
+					Priority:      100,
+					Condition:     "true",
+					OperationName: `"processed"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	
+	droppedBool := spans.AppendEmpty()
+	droppedBool.SetName("dropped")
+	droppedBool.Attributes().PutBool("sampling.keep", false)
+	
+	droppedStr := spans.AppendEmpty()
+	droppedStr.SetName("dropped")
+	droppedStr.Attributes().PutStr("sampling.keep", "false")
+	
+	kept := spans.AppendEmpty()
+	kept.SetName("kept")
+	kept.Attributes().PutBool("sampling.keep", true)
+	
+	unmarked := spans.AppendEmpty()
+	unmarked.SetName("unmarked")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	// Marked spans pass through unchanged
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, "dropped", resultSpans.At(i).Name())
+		_, exists := resultSpans.At(i).Attributes().Get("operation.name")
+		assert.False(t, exists)
+	}
+	
+	// Other spans are processed
+	assert.Equal(t, "processed", resultSpans.At(2).Name())
+	assert.Equal(t, "processed", resultSpans.At(3).Name())
+}
+
+func TestProcessTraces_EnrichSetNameIfGeneric(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:                true,
+			Mode:                   ModeEnrich,
+			EnrichSetNameIfGeneric: true,
+			PreserveOriginalName:   true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http_server",
+					Priority:      100,
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+					OperationType: `"http"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"GET", "HTTP GET", "UserController.show"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.Attributes().PutStr("http.request.method", "GET")
+		span.Attributes().PutStr("http.route", "/users/{id}")
+	}
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	spans = result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+
+	// Generic names are replaced, keeping the original
+	for i, original := range []string{"GET", "HTTP GET"} {
+		span := spans.At(i)
+		assert.Equal(t, "GET /users/{id}", span.Name())
+		originalName, exists := span.Attributes().Get("name.original")
+		require.True(t, exists)
+		assert.Equal(t, original, originalName.Str())
+	}
+
+	// A specific name is left alone and only enriched
+	specific := spans.At(2)
+	assert.Equal(t, "UserController.show", specific.Name())
+	operationName, _ := specific.Attributes().Get("operation.name")
+	assert.Equal(t, "GET /users/{id}", operationName.Str())
+	_, exists := specific.Attributes().Get("name.original")
+	assert.False(t, exists)
+}
+
+func TestProcessTraces_EnrichGenericNamePattern(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:                true,
+			Mode:                   ModeEnrich,
+			EnrichSetNameIfGeneric: true,
+			GenericNamePattern:     `^unknown$`,
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("unknown")
+	spans.AppendEmpty().SetName("GET")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	spans = result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "operation", spans.At(0).Name())
+	assert.Equal(t, "GET", spans.At(1).Name())
+}
+
+func TestProcessTraces_DistinctOperationTypes(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.request.method"] != nil`,
+					OperationName: `attributes["http.request.method"]`,
+					OperationType: `"http"`,
+				},
+				{
+					ID:            "db",
+					Priority:      200,
+					Condition:     `attributes["db.system"] != nil`,
+					OperationName: `attributes["db.system"]`,
+					OperationType: `"database"`,
+				},
+				{
+					ID:            "fallback",
+					Priority:      300,
+					Condition:     "true",
+					OperationName: "name",
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().Attributes().PutStr("http.request.method", "GET")
+	spans.AppendEmpty().Attributes().PutStr("http.request.method", "POST")
+	spans.AppendEmpty().Attributes().PutStr("db.system", "postgresql")
+	// Set by an earlier stage
+	upstream := spans.AppendEmpty()
+	upstream.Attributes().PutStr("operation.name", "publish orders")
+	upstream.Attributes().PutStr("operation.type", "messaging")
+	// No operation type at all
+	spans.AppendEmpty().SetName("internal")
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	metadatatest.AssertEqualProcessorSemconvDistinctOperationTypes(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 3}},
+		metricdatatest.IgnoreTimestamp())
+	
+	// The gauge reflects the latest batch
+	traces = ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().PutStr("db.system", "mysql")
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	metadatatest.AssertEqualProcessorSemconvDistinctOperationTypes(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestProcessTraces_SkipMatchingOperationName(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:                   true,
+			Mode:                      ModeEnrich,
+			SkipMatchingOperationName: true,
+			EnrichSetNameIfGeneric:    true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http_paths",
+					Priority:      100,
+					Condition:     `attributes["url.path"] != nil`,
+					OperationName: `Concat(["GET", NormalizePath(attributes["url.path"])], " ")`,
+					OperationType: `"http"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	
+	matchingSpan := spans.AppendEmpty()
+	matchingSpan.SetName("GET /users")
+	matchingSpan.Attributes().PutStr("url.path", "/users")
+	
+	differingSpan := spans.AppendEmpty()
+	differingSpan.SetName("GET /users/123")
+	differingSpan.Attributes().PutStr("url.path", "/users/123")
+	
+	genericSpan := spans.AppendEmpty()
+	genericSpan.SetName("GET")
+	genericSpan.Attributes().PutStr("url.path", "/users/456")
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	resultSpans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	
+	// The operation name equals the span name, so only the type is added
+	assert.Equal(t, map[string]any{
+		"url.path":       "/users",
+		"operation.type": "http",
+	}, resultSpans.At(0).Attributes().AsRaw())
+	
+	// A differing operation name is written as usual
+	assert.Equal(t, "GET /users/123", resultSpans.At(1).Name())
+	operationName, exists := resultSpans.At(1).Attributes().Get("operation.name")
+	require.True(t, exists)
+	assert.Equal(t, "GET /users/{id}", operationName.Str())
+	
+	// A replaced generic name would be repeated by the attribute
+	assert.Equal(t, "GET /users/{id}", resultSpans.At(2).Name())
+	_, exists = resultSpans.At(2).Attributes().Get("operation.name")
+	assert.False(t, exists)
+}
+
+func TestProcessTraces_MaxSpansPerBatch(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:          true,
+			Mode:             ModeEnforce,
+			MaxSpansPerBatch: 3,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.request.method"] != nil`,
+					OperationName: `Concat([attributes["http.request.method"], NormalizePath(attributes["url.path"])], " ")`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	
+	// The cap counts spans across scopes and resources of the batch
+	traces := ptrace.NewTraces()
+	first := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	second := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 2; i++ {
+		for _, spans := range []ptrace.SpanSlice{first, second} {
+			span := spans.AppendEmpty()
+			span.SetName(fmt.Sprintf("GET /users/%d", i))
+			span.Attributes().PutStr("http.request.method", "GET")
+			span.Attributes().PutStr("url.path", fmt.Sprintf("/users/%d", i))
+		}
+	}
+	
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	// Both spans of the first resource and the first of the second are processed
+	for _, span := range []ptrace.Span{first.At(0), first.At(1), second.At(0)} {
+		assert.Equal(t, "GET /users/{id}", span.Name())
+	}
+	
+	// The tail is untouched
+	tail := second.At(1)
+	assert.Equal(t, "GET /users/1", tail.Name())
+	assert.Equal(t, map[string]any{"http.request.method": "GET", "url.path": "/users/1"}, tail.Attributes().AsRaw())
+	
+	metadatatest.AssertEqualProcessorSemconvSpansSkippedOverLimit(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorSemconvSpansProcessed(t, tel,
+		[]metricdata.DataPoint[int64]{
+			{
+				Value:      3,
+				Attributes: attribute.NewSet(attribute.String("signal_type", "traces")),
+			},
+		}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestCompileRules_SharedExpressions(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnforce,
+			Rules: []OTTLRule{
+				{
+					ID:            "server",
+					Priority:      100,
+					SpanKind:      []string{"server"},
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.method"], attributes["http.route"]], " ")`,
+					OperationType: `"http"`,
+				},
+				{
+					ID:            "client",
+					Priority:      200,
+					SpanKind:      []string{"client"},
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.method"], attributes["http.route"]], " ")`,
+					OperationType: `"http_client"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+	
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+	
+	// Identical expression text is parsed once and shared
+	require.Len(t, processor.compiledRules, 2)
+	assert.Same(t, processor.compiledRules[0].OperationNames[0], processor.compiledRules[1].OperationNames[0])
+	assert.NotSame(t, processor.compiledRules[0].OperationType, processor.compiledRules[1].OperationType)
+	
+	// Sharing does not change the results of either rule
+	traces := ptrace.NewTraces()
+	ss := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for _, kind := range []ptrace.SpanKind{ptrace.SpanKindServer, ptrace.SpanKindClient} {
+		span := ss.Spans().AppendEmpty()
+		span.SetName("original")
+		span.SetKind(kind)
+		span.Attributes().PutStr("http.method", "GET")
+		span.Attributes().PutStr("http.route", "/users")
+	}
+	
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	
+	spans := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i, expectedType := range []string{"http", "http_client"} {
+		assert.Equal(t, "GET /users", spans.At(i).Name())
+		operationType, _ := spans.At(i).Attributes().Get("operation.type")
+		assert.Equal(t, expectedType, operationType.Str())
+	}
+}
+
+func BenchmarkCompileRules(b *testing.B) {
+	// A large config generated from a handful of templates
+	templates := []OTTLRule{
+		{
+			Condition:     `attributes["http.route"] != nil and attributes["http.method"] != nil`,
+			OperationName: `Concat([attributes["http.method"], attributes["http.route"]], " ")`,
+			OperationType: `"http"`,
+		},
+		{
+			Condition:     `attributes["db.statement"] != nil`,
+			OperationName: `ParseSQL(attributes["db.statement"])`,
+			OperationType: `attributes["db.system"]`,
+		},
+		{
+			Condition:     `attributes["messaging.destination.name"] != nil`,
+			OperationName: `Concat(["publish", attributes["messaging.destination.name"]], " ")`,
+			OperationType: `"messaging"`,
+		},
+	}
+	rules := make([]OTTLRule, 0, 300)
+	for i := 0; i < 300; i++ {
+		rule := templates[i%len(templates)]
+		rule.ID = fmt.Sprintf("rule_%d", i)
+		rule.Priority = i
+		rules = append(rules, rule)
+	}
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Rules:   rules,
+		},
+	}
+	require.NoError(b, cfg.Validate())
+	
+	settings := processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(settings)
+	
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, settings); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"reflect"
+	"runtime/debug"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Attributes written by stamp_provenance
+const (
+	provenanceProcessedAttribute = "semconv.processed"
+	provenanceVersionAttribute   = "semconv.processor.version"
+)
+
+// unknownProcessorVersion is stamped when the binary carries no module information
+const unknownProcessorVersion = "unknown"
+
+// stampProvenance marks a span the processor modified. Each processing step reports
+// whether it changed the span, so no copy of the span is needed to detect a modification.
+func stampProvenance(span ptrace.Span, version string) {
+	span.Attributes().PutBool(provenanceProcessedAttribute, true)
+	span.Attributes().PutStr(provenanceVersionAttribute, version)
+}
+
+// processorVersion returns the version of the module this package was built from,
+// "(devel)" for local builds
+func processorVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownProcessorVersion
+	}
+
+	// The most specific module containing this package wins, in case it is ever split out
+	pkgPath := reflect.TypeOf(semconvProcessor{}).PkgPath()
+	version, matched := unknownProcessorVersion, ""
+	for _, mod := range append([]*debug.Module{&info.Main}, info.Deps...) {
+		if mod == nil || len(mod.Path) <= len(matched) {
+			continue
+		}
+		if pkgPath == mod.Path || strings.HasPrefix(pkgPath, mod.Path+"/") {
+			matched = mod.Path
+			version = mod.Version
+			if mod.Replace != nil && mod.Replace.Version != "" {
+				version = mod.Replace.Version
+			}
+		}
+	}
+	if version == "" {
+		return unknownProcessorVersion
+	}
+	return version
+}
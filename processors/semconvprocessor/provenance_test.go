@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestProcessTraces_StampProvenance(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:         true,
+			Mode:            ModeEnforce,
+			StampProvenance: true,
+			Rules: []OTTLRule{
+				{
+					ID:            "http_route",
+					Priority:      100,
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `attributes["http.route"]`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	set := processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, set)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	modified := spans.AppendEmpty()
+	modified.SetName("GET")
+	modified.Attributes().PutStr("http.route", "/users/{id}")
+	untouched := spans.AppendEmpty()
+	untouched.SetName("internal work")
+
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	processed, exists := modified.Attributes().Get(provenanceProcessedAttribute)
+	require.True(t, exists)
+	assert.True(t, processed.Bool())
+	version, exists := modified.Attributes().Get(provenanceVersionAttribute)
+	require.True(t, exists)
+	assert.NotEmpty(t, version.Str())
+
+	_, exists = untouched.Attributes().Get(provenanceProcessedAttribute)
+	assert.False(t, exists)
+	_, exists = untouched.Attributes().Get(provenanceVersionAttribute)
+	assert.False(t, exists)
+	assert.Equal(t, "internal work", untouched.Name())
+}
+
+func TestProcessTraces_StampProvenanceExceptionEvents(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:         true,
+			Mode:            ModeEnforce,
+			StampProvenance: true,
+			ExceptionEvents: ExceptionEventsConfig{ShortenType: true},
+			Rules: []OTTLRule{
+				{
+					ID:            "http_route",
+					Priority:      100,
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `attributes["http.route"]`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	set := processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, set)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	normalized := spans.AppendEmpty()
+	normalized.SetName("checkout")
+	normalized.Events().AppendEmpty().SetName("exception")
+	normalized.Events().At(0).Attributes().PutStr("exception.type", "com.acme.PaymentException")
+	alreadyShort := spans.AppendEmpty()
+	alreadyShort.SetName("checkout")
+	alreadyShort.Events().AppendEmpty().SetName("exception")
+	alreadyShort.Events().At(0).Attributes().PutStr("exception.type", "PaymentException")
+
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	exceptionType, _ := normalized.Events().At(0).Attributes().Get("exception.type")
+	assert.Equal(t, "PaymentException", exceptionType.Str())
+	_, exists := normalized.Attributes().Get(provenanceProcessedAttribute)
+	assert.True(t, exists)
+	_, exists = alreadyShort.Attributes().Get(provenanceProcessedAttribute)
+	assert.False(t, exists)
+}
+
+func TestStampProvenance(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(cfg *SpanProcessingConfig)
+		setup     func(span ptrace.Span)
+		stamp     bool
+	}{
+		{
+			name:      "untouched",
+			configure: func(*SpanProcessingConfig) {},
+			setup:     func(ptrace.Span) {},
+			stamp:     false,
+		},
+		{
+			name:      "rule applied",
+			configure: func(*SpanProcessingConfig) {},
+			setup:     func(span ptrace.Span) { span.Attributes().PutStr("http.route", "/users/{id}") },
+			stamp:     true,
+		},
+		{
+			name:      "http method normalized",
+			configure: func(cfg *SpanProcessingConfig) { cfg.NormalizeHTTPMethod = true },
+			setup:     func(span ptrace.Span) { span.Attributes().PutStr("http.request.method", "get") },
+			stamp:     true,
+		},
+		{
+			name:      "http method already normalized",
+			configure: func(cfg *SpanProcessingConfig) { cfg.NormalizeHTTPMethod = true },
+			setup:     func(span ptrace.Span) { span.Attributes().PutStr("http.request.method", "GET") },
+			stamp:     false,
+		},
+		{
+			name:      "net attribute migrated",
+			configure: func(cfg *SpanProcessingConfig) { cfg.NetToServerClient = true },
+			setup:     func(span ptrace.Span) { span.Attributes().PutStr("net.protocol.name", "http") },
+			stamp:     true,
+		},
+		{
+			name:      "name promoted from attribute",
+			configure: func(cfg *SpanProcessingConfig) { cfg.UseAttributeAsName = "span.name" },
+			setup:     func(span ptrace.Span) { span.Attributes().PutStr("span.name", "checkout") },
+			stamp:     true,
+		},
+		{
+			name:      "promoted name unchanged",
+			configure: func(cfg *SpanProcessingConfig) { cfg.UseAttributeAsName = "span.name" },
+			setup:     func(span ptrace.Span) { span.Attributes().PutStr("span.name", "internal work") },
+			stamp:     false,
+		},
+		{
+			name: "error event attached",
+			configure: func(cfg *SpanProcessingConfig) {
+				cfg.AttachErrorEvents = true
+				cfg.Rules[0].Condition = `true`
+				// NormalizePath needs a string; an int attribute makes evaluation fail
+				cfg.Rules[0].OperationName = `NormalizePath(attributes["http.target"])`
+			},
+			setup: func(span ptrace.Span) { span.Attributes().PutInt("http.target", 42) },
+			stamp: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:         true,
+					Mode:            ModeEnforce,
+					StampProvenance: true,
+					Rules: []OTTLRule{
+						{
+							ID:            "http_route",
+							Priority:      100,
+							Condition:     `attributes["http.route"] != nil`,
+							OperationName: `attributes["http.route"]`,
+						},
+					},
+				},
+			}
+			tt.configure(&cfg.SpanProcessing)
+			require.NoError(t, cfg.Validate())
+
+			set := processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings
+			telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+			require.NoError(t, err)
+			processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, set)
+			require.NoError(t, err)
+
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("internal work")
+			tt.setup(span)
+
+			_, err = processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			_, exists := span.Attributes().Get(provenanceProcessedAttribute)
+			assert.Equal(t, tt.stamp, exists)
+			_, exists = span.Attributes().Get(provenanceVersionAttribute)
+			assert.Equal(t, tt.stamp, exists)
+		})
+	}
+}
+
+func TestProcessorVersion(t *testing.T) {
+	assert.NotEmpty(t, processorVersion())
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+// defaultMaxRegexInputBytes is the largest input user-supplied regular expressions
+// are applied to unless max_regex_input_bytes says otherwise
+const defaultMaxRegexInputBytes = 16384
+
+// unlimitedRegexInputBytes is the max_regex_input_bytes value that disables the guard
+const unlimitedRegexInputBytes = -1
+
+// regexGuard skips user-supplied regular expressions on oversized inputs. RE2 runs in
+// linear time, but a huge attribute or log body still costs CPU on every pattern.
+type regexGuard struct {
+	maxBytes int                 // Inputs longer than this are skipped; unlimitedRegexInputBytes disables the guard
+	skipped  metric.Int64Counter // Counts skipped inputs
+}
+
+// allow reports whether a regular expression may be applied to input, counting the
+// inputs that are skipped. A nil guard allows everything.
+func (g *regexGuard) allow(ctx context.Context, input string) bool {
+	if g == nil || g.maxBytes == unlimitedRegexInputBytes || len(input) <= g.maxBytes {
+		return true
+	}
+	g.skipped.Add(ctx, 1)
+	return false
+}
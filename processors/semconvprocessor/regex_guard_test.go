@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadatatest"
+)
+
+func TestProcessTraces_MaxRegexInputBytes(t *testing.T) {
+	cfg := &Config{
+		Enabled:            true,
+		MaxRegexInputBytes: 64,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			DestinationNormalizers: []KeyRewrite{
+				{Pattern: `-partition-\d+$`},
+			},
+			Rules: []OTTLRule{
+				{ID: "all", Priority: 100, Condition: "true", OperationName: `"operation"`},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	processor, err := newSemconvProcessor(zap.NewNop(), cfg, telemetryBuilder, tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	oversized := strings.Repeat("x", 100) + "-partition-3"
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().Attributes().PutStr("messaging.destination.name", "orders-partition-3")
+	spans.AppendEmpty().Attributes().PutStr("messaging.destination.name", oversized)
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	spans = result.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+
+	// A normal input is rewritten
+	destination, _ := spans.At(0).Attributes().Get("messaging.destination.name")
+	assert.Equal(t, "orders", destination.Str())
+
+	// An oversized input is left unchanged and counted
+	destination, _ = spans.At(1).Attributes().Get("messaging.destination.name")
+	assert.Equal(t, oversized, destination.Str())
+	metadatatest.AssertEqualProcessorSemconvRegexInputsSkipped(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestRegexReplace_MaxRegexInputBytes(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	guard := &regexGuard{maxBytes: 16, skipped: telemetryBuilder.ProcessorSemconvRegexInputsSkipped}
+
+	replace := func(value string) any {
+		args := &regexReplaceArguments[any]{
+			Value: ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return value, nil
+				},
+			},
+			Pattern:     `\d+`,
+			Replacement: "N",
+		}
+		exprFunc, err := createRegexReplaceFunction[any](guard)(ottl.FunctionContext{}, args)
+		require.NoError(t, err)
+		result, err := exprFunc(context.Background(), nil)
+		require.NoError(t, err)
+		return result
+	}
+
+	assert.Equal(t, "order N", replace("order 42"))
+	assert.Equal(t, "order 42 of batch 1234567", replace("order 42 of batch 1234567"))
+	metadatatest.AssertEqualProcessorSemconvRegexInputsSkipped(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestRegexGuard_Disabled(t *testing.T) {
+	var guard *regexGuard
+	assert.True(t, guard.allow(context.Background(), strings.Repeat("x", 1<<20)))
+	assert.True(t, (&regexGuard{maxBytes: unlimitedRegexInputBytes}).allow(context.Background(), strings.Repeat("x", 1<<20)))
+}
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// compiledRewrite is a KeyRewrite with its pattern compiled
+type compiledRewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// compileRewrites compiles the patterns of the given rewrites, keeping their order
+func compileRewrites(rewrites []KeyRewrite) ([]compiledRewrite, error) {
+	compiled := make([]compiledRewrite, 0, len(rewrites))
+	for i, rewrite := range rewrites {
+		pattern, err := regexp.Compile(rewrite.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rewrite %d has invalid pattern %q: %w", i, rewrite.Pattern, err)
+		}
+		compiled = append(compiled, compiledRewrite{pattern: pattern, replacement: rewrite.Replacement})
+	}
+	return compiled, nil
+}
+
+// applyRewrites applies each rewrite in order to value
+func applyRewrites(value string, rewrites []compiledRewrite) string {
+	for _, rewrite := range rewrites {
+		value = rewrite.pattern.ReplaceAllString(value, rewrite.replacement)
+	}
+	return value
+}
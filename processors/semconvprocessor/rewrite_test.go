@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRewrites(t *testing.T) {
+	rewrites, err := compileRewrites([]KeyRewrite{
+		{Pattern: `-partition-\d+$`, Replacement: ""},
+		{Pattern: `^tenant-(\w+)\.`, Replacement: "tenant."},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "orders-partition-3", expected: "orders"},
+		{value: "orders", expected: "orders"},
+		{value: "tenant-acme.orders-partition-12", expected: "tenant.orders"},
+		{value: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			assert.Equal(t, tt.expected, applyRewrites(tt.value, rewrites))
+		})
+	}
+}
+
+func TestCompileRewrites_InvalidPattern(t *testing.T) {
+	_, err := compileRewrites([]KeyRewrite{{Pattern: `(`}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rewrite 0 has invalid pattern")
+}
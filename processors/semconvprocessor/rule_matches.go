@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+// countRuleMatch records that a rule matched a span in the current rule_match_window
+func (sp *semconvProcessor) countRuleMatch(ruleID string) {
+	if sp.ruleMatches == nil {
+		return
+	}
+	sp.ruleMatchMu.Lock()
+	sp.ruleMatches[ruleID]++
+	sp.ruleMatchMu.Unlock()
+}
+
+// reportUnmatchedRules ends the current rule_match_window: it records how many rules
+// matched no span, logs their IDs and starts the next window from zero. A window
+// without spans proves nothing, e.g. in the processors of the other signals, which
+// share the config, so it is not reported.
+func (sp *semconvProcessor) reportUnmatchedRules(ctx context.Context) {
+	if sp.ruleWindowSpans.Swap(0) == 0 {
+		return
+	}
+	
+	var unmatched []string
+	sp.ruleMatchMu.Lock()
+	for ruleID, count := range sp.ruleMatches {
+		if count == 0 {
+			unmatched = append(unmatched, ruleID)
+		}
+		sp.ruleMatches[ruleID] = 0
+	}
+	sp.ruleMatchMu.Unlock()
+	
+	sp.telemetry.ProcessorSemconvRuleNeverMatched.Record(ctx, int64(len(unmatched)))
+	if len(unmatched) > 0 {
+		sort.Strings(unmatched)
+		sp.logger.Warn("rules matched no span during the rule match window, they may be stale",
+			zap.Strings("rule_ids", unmatched),
+			zap.Duration("window", sp.config.RuleMatchWindow))
+	}
+}
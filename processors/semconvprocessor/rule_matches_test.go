@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadatatest"
+)
+
+func TestReportUnmatchedRules(t *testing.T) {
+	processor, tel := newTestProcessor(t, &Config{
+		Enabled:         true,
+		RuleMatchWindow: time.Hour,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeAudit,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.request.method"] != nil`,
+					OperationName: `attributes["http.request.method"]`,
+				},
+				{
+					ID:            "legacy_rpc",
+					Priority:      200,
+					Condition:     `attributes["rpc.legacy"] != nil`,
+					OperationName: `"rpc"`,
+				},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().PutStr("http.request.method", "GET")
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// legacy_rpc never matched during the window
+	processor.reportUnmatchedRules(context.Background())
+	metadatatest.AssertEqualProcessorSemconvRuleNeverMatched(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+
+	// Each window starts from zero, so the http match does not carry over
+	traces = ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("internal")
+	_, err = processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	processor.reportUnmatchedRules(context.Background())
+	metadatatest.AssertEqualProcessorSemconvRuleNeverMatched(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestReportUnmatchedRules_WindowWithoutSpans(t *testing.T) {
+	processor, tel := newTestProcessor(t, &Config{
+		Enabled:         true,
+		RuleMatchWindow: time.Hour,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeAudit,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.request.method"] != nil`,
+					OperationName: `attributes["http.request.method"]`,
+				},
+				{
+					ID:            "legacy_rpc",
+					Priority:      200,
+					Condition:     `attributes["rpc.legacy"] != nil`,
+					OperationName: `"rpc"`,
+				},
+			},
+		},
+	})
+
+	// Without spans, no rule can be judged stale
+	processor.reportUnmatchedRules(context.Background())
+	_, err := tel.GetMetric("otelcol_processor_semconv_rule_never_matched")
+	assert.Error(t, err)
+}
+
+func TestRuleMatchWindow_Periodic(t *testing.T) {
+	processor, tel := newTestProcessor(t, &Config{
+		Enabled:         true,
+		Benchmark:       true,
+		RuleMatchWindow: 10 * time.Millisecond,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "http",
+					Priority:      100,
+					Condition:     `attributes["http.request.method"] != nil`,
+					OperationName: `attributes["http.request.method"]`,
+				},
+				{
+					ID:            "legacy_rpc",
+					Priority:      200,
+					Condition:     `attributes["rpc.legacy"] != nil`,
+					OperationName: `"rpc"`,
+				},
+			},
+		},
+	})
+	require.NoError(t, processor.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, processor.shutdown(context.Background())) })
+
+	traces := ptrace.NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().Attributes().PutStr("http.request.method", "GET")
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err := tel.GetMetric("otelcol_processor_semconv_rule_never_matched")
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+	metadatatest.AssertEqualProcessorSemconvRuleNeverMatched(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+}
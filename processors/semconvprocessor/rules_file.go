@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// rulesFile is the layout of a file listed in rules_files
+type rulesFile struct {
+	Rules []OTTLRule `mapstructure:"rules"`
+}
+
+// loadRulesFiles reads the rules of every file in order. With namespace set, rule IDs
+// are prefixed with the file name without its extension, e.g. http.get for get in http.yaml
+func loadRulesFiles(paths []string, namespace bool) ([]OTTLRule, error) {
+	var rules []OTTLRule
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rules file %q: %w", path, err)
+		}
+
+		retrieved, err := confmap.NewRetrievedFromYAML(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rules file %q: %w", path, err)
+		}
+		conf, err := retrieved.AsConf()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rules file %q: %w", path, err)
+		}
+
+		var file rulesFile
+		if err := conf.Unmarshal(&file); err != nil {
+			return nil, fmt.Errorf("failed to decode rules file %q: %w", path, err)
+		}
+
+		prefix := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		for _, rule := range file.Rules {
+			// Empty IDs are left empty so validation still reports them
+			if namespace && rule.ID != "" {
+				rule.ID = prefix + "." + rule.ID
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
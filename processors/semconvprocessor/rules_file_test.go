@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRulesFiles writes the http and db rule files used by the tests, which share the ID "fallback"
+func writeRulesFiles(t *testing.T) []string {
+	dir := t.TempDir()
+	httpFile := filepath.Join(dir, "http.yaml")
+	require.NoError(t, os.WriteFile(httpFile, []byte(`
+rules:
+  - id: route
+    priority: 100
+    condition: attributes["http.route"] != nil
+    operation_name: attributes["http.route"]
+  - id: fallback
+    priority: 900
+    condition: attributes["http.request.method"] != nil
+    operation_name: attributes["http.request.method"]
+`), 0o600))
+	dbFile := filepath.Join(dir, "db.yaml")
+	require.NoError(t, os.WriteFile(dbFile, []byte(`
+rules:
+  - id: fallback
+    priority: 800
+    condition: attributes["db.system.name"] != nil
+    operation_name: attributes["db.system.name"]
+`), 0o600))
+	return []string{httpFile, dbFile}
+}
+
+func TestRulesFiles_NamespacedAndSorted(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:          true,
+			RulesFiles:       writeRulesFiles(t),
+			NamespaceRuleIDs: true,
+			Rules: []OTTLRule{
+				{ID: "inline", Priority: 500, Condition: "true", OperationName: `"inline"`},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	// Priority ordering is global across inline rules and every file
+	var ids []string
+	for _, rule := range cfg.SpanProcessing.Rules {
+		ids = append(ids, rule.ID)
+	}
+	assert.Equal(t, []string{"http.route", "inline", "db.fallback", "http.fallback"}, ids)
+
+	// Validating again does not load the files a second time
+	require.NoError(t, cfg.Validate())
+	assert.Len(t, cfg.SpanProcessing.Rules, 4)
+}
+
+func TestRulesFiles_EqualPriorityKeepsFileOrder(t *testing.T) {
+	// Two files alternating between the same two priorities, with enough rules that an
+	// unstable sort would reorder the ties
+	dir := t.TempDir()
+	var files, high, low []string
+	for _, name := range []string{"first", "second"} {
+		content := "rules:\n"
+		for i := 0; i < 20; i++ {
+			id := fmt.Sprintf("rule%d", i)
+			priority := 100
+			if i%2 == 1 {
+				priority = 200
+			}
+			content += fmt.Sprintf("  - id: %s\n    priority: %d\n    condition: \"true\"\n    operation_name: '\"%s\"'\n", id, priority, id)
+			if priority == 100 {
+				high = append(high, name+"."+id)
+			} else {
+				low = append(low, name+"."+id)
+			}
+		}
+		file := filepath.Join(dir, name+".yaml")
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+		files = append(files, file)
+	}
+
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:          true,
+			RulesFiles:       files,
+			NamespaceRuleIDs: true,
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var ids []string
+	for _, rule := range cfg.SpanProcessing.Rules {
+		ids = append(ids, rule.ID)
+	}
+	assert.Equal(t, append(high, low...), ids)
+}
+
+func TestRulesFiles_DuplicateIDsWithoutNamespace(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:    true,
+			RulesFiles: writeRulesFiles(t),
+		},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate rule ID: fallback")
+}
+
+func TestRulesFiles_Errors(t *testing.T) {
+	dir := t.TempDir()
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("rules: ["), 0o600))
+	unknownKeyFile := filepath.Join(dir, "unknown.yaml")
+	require.NoError(t, os.WriteFile(unknownKeyFile, []byte("rulez: []"), 0o600))
+
+	tests := []struct {
+		name   string
+		path   string
+		errMsg string
+	}{
+		{name: "missing file", path: filepath.Join(dir, "missing.yaml"), errMsg: "failed to read rules file"},
+		{name: "invalid yaml", path: invalidFile, errMsg: "failed to parse rules file"},
+		{name: "unknown key", path: unknownKeyFile, errMsg: "failed to decode rules file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadRulesFiles([]string{tt.path}, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,640 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// maxUnreachableSchemaVersions bounds the unreachable source versions remembered so
+// each is only logged once
+const maxUnreachableSchemaVersions = 100
+
+// maxInvalidSchemaTargets bounds the invalid per-resource target versions remembered so
+// each is only logged once
+const maxInvalidSchemaTargets = 100
+
+// schemaFile is the layout of an OpenTelemetry schema file. Only the changes the
+// processor applies are decoded; other sections and change types are ignored
+type schemaFile struct {
+	FileFormat string                       `mapstructure:"file_format"`
+	SchemaURL  string                       `mapstructure:"schema_url"`
+	Versions   map[string]schemaFileVersion `mapstructure:"versions"`
+}
+
+// schemaFileVersion lists the changes that lead from the previous version to this one
+type schemaFileVersion struct {
+	All       schemaFileSection `mapstructure:"all"`
+	Resources schemaFileSection `mapstructure:"resources"`
+	Spans     schemaFileSection `mapstructure:"spans"`
+	Metrics   schemaFileSection `mapstructure:"metrics"`
+	Logs      schemaFileSection `mapstructure:"logs"`
+}
+
+type schemaFileSection struct {
+	Changes []schemaFileChange `mapstructure:"changes"`
+}
+
+type schemaFileChange struct {
+	RenameAttributes *schemaFileRenameAttributes `mapstructure:"rename_attributes"`
+	RenameMetrics    map[string]string           `mapstructure:"rename_metrics"`
+}
+
+type schemaFileRenameAttributes struct {
+	AttributeMap   map[string]string `mapstructure:"attribute_map"`
+	ApplyToSpans   []string          `mapstructure:"apply_to_spans"`
+	ApplyToMetrics []string          `mapstructure:"apply_to_metrics"`
+}
+
+// loadSchemaFile reads and checks an OpenTelemetry schema file
+func loadSchemaFile(path string) (*schemaFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file %q: %w", path, err)
+	}
+
+	retrieved, err := confmap.NewRetrievedFromYAML(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema file %q: %w", path, err)
+	}
+	conf, err := retrieved.AsConf()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema file %q: %w", path, err)
+	}
+
+	var file schemaFile
+	if err := conf.Unmarshal(&file, confmap.WithIgnoreUnused()); err != nil {
+		return nil, fmt.Errorf("failed to decode schema file %q: %w", path, err)
+	}
+
+	if !strings.HasPrefix(file.FileFormat, "1.") {
+		return nil, fmt.Errorf("schema file %q has unsupported file_format %q, must be 1.x", path, file.FileFormat)
+	}
+	if !strings.Contains(file.SchemaURL, "/") {
+		return nil, fmt.Errorf("schema file %q has an invalid schema_url %q", path, file.SchemaURL)
+	}
+	if len(file.Versions) == 0 {
+		return nil, fmt.Errorf("schema file %q lists no versions", path)
+	}
+	for version := range file.Versions {
+		if _, err := parseSchemaVersion(version); err != nil {
+			return nil, fmt.Errorf("schema file %q: %w", path, err)
+		}
+	}
+
+	// The file describes the transitions up to its own version, which must be the newest
+	urlVersion := file.SchemaURL[strings.LastIndex(file.SchemaURL, "/")+1:]
+	if newest := file.newestVersion(); urlVersion != newest {
+		return nil, fmt.Errorf("schema file %q has schema_url version %q, but its newest version is %q", path, urlVersion, newest)
+	}
+	return &file, nil
+}
+
+// checkTarget reports an error when the target version is not listed, since no
+// source version could reach it
+func (f *schemaFile) checkTarget(target string) error {
+	if _, listed := f.Versions[target]; !listed {
+		return fmt.Errorf("schema_migration.target_version %q is not listed in the schema file, must be one of %s", target, strings.Join(f.sortedVersions(), ", "))
+	}
+	return nil
+}
+
+// newestVersion returns the highest version listed in the file
+func (f *schemaFile) newestVersion() string {
+	versions := f.sortedVersions()
+	return versions[len(versions)-1]
+}
+
+// sortedVersions returns the listed versions in ascending order
+func (f *schemaFile) sortedVersions() []string {
+	versions := make([]string, 0, len(f.Versions))
+	for version := range f.Versions {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		a, _ := parseSchemaVersion(versions[i])
+		b, _ := parseSchemaVersion(versions[j])
+		return a.less(b)
+	})
+	return versions
+}
+
+// schemaVersion is a parsed major.minor.patch schema version
+type schemaVersion [3]int
+
+func parseSchemaVersion(version string) (schemaVersion, error) {
+	var parsed schemaVersion
+	parts := strings.Split(version, ".")
+	if len(parts) != len(parsed) {
+		return parsed, fmt.Errorf("invalid schema version %q, must be major.minor.patch", version)
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return parsed, fmt.Errorf("invalid schema version %q, must be major.minor.patch", version)
+		}
+		parsed[i] = n
+	}
+	return parsed, nil
+}
+
+func (v schemaVersion) less(other schemaVersion) bool {
+	for i := range v {
+		if v[i] != other[i] {
+			return v[i] < other[i]
+		}
+	}
+	return false
+}
+
+// schemaRenames is a rename_attributes or rename_metrics change, oriented in the
+// direction of the migration
+type schemaRenames struct {
+	renames     []attributeRename
+	spans       map[string]bool   // Span names from apply_to_spans; nil applies to all spans
+	metrics     map[string]bool   // Metric names from apply_to_metrics; nil applies to all metrics
+	metricNames map[string]string // Metric renames, nil for attribute renames
+}
+
+// schemaChanges are the renames of one version step, by the data they apply to
+type schemaChanges struct {
+	all       []schemaRenames
+	resources []schemaRenames
+	spans     []schemaRenames
+	metrics   []schemaRenames
+	logs      []schemaRenames
+	downgrade bool // Reverts the version, so the sections apply in reverse order
+}
+
+// schemaMigrator migrates telemetry from the schema version in its schema URL to the
+// target version, if both belong to the schema file's family
+type schemaMigrator struct {
+	logger           *zap.Logger
+	urlPrefix        string                     // schema_url without the version, e.g. https://opentelemetry.io/schemas/
+	target           string                     // Version listed sources are migrated to
+	targetURL        string                     // Written to migrated resources and scopes
+	plans            map[string][]schemaChanges // Steps to the target, keyed by source version
+	targetAttribute  string                     // Resource attribute naming a resource's own target, empty when not configured
+	targets          map[string]*schemaMigrator // Migrators to every listed version, keyed by version; nil without targetAttribute
+	versionAttribute string                     // Resource attribute the target version is written to after a migration, empty when not configured
+	unreachable      map[string]bool            // Source versions already logged, bounded by maxUnreachableSchemaVersions
+	invalidTargets   map[string]bool            // Per-resource targets already logged, bounded by maxInvalidSchemaTargets
+	reportedMu       sync.Mutex                 // Guards unreachable and invalidTargets across concurrent batches
+}
+
+// newSchemaMigrator plans the migration from every version in the file to the target.
+// Upgrades apply the changes of each later version in order; downgrades revert them
+// newest first with the renames inverted. With a targetAttribute, the migration to every
+// other listed version is planned as well, for resources that name their own target.
+// With a versionAttribute, migrated resources record the version they were migrated to.
+func newSchemaMigrator(logger *zap.Logger, file *schemaFile, target, targetAttribute, versionAttribute string) (*schemaMigrator, error) {
+	if err := file.checkTarget(target); err != nil {
+		return nil, err
+	}
+
+	m := &schemaMigrator{
+		logger:           logger,
+		urlPrefix:        file.SchemaURL[:strings.LastIndex(file.SchemaURL, "/")+1],
+		target:           target,
+		versionAttribute: versionAttribute,
+		plans:            make(map[string][]schemaChanges, len(file.Versions)),
+		unreachable:      make(map[string]bool),
+		invalidTargets:   make(map[string]bool),
+	}
+	m.targetURL = m.urlPrefix + target
+
+	versions := file.sortedVersions()
+	var targetIndex int
+	for i, version := range versions {
+		if version == target {
+			targetIndex = i
+		}
+	}
+	for sourceIndex, source := range versions {
+		var plan []schemaChanges
+		for i := sourceIndex + 1; i <= targetIndex; i++ {
+			plan = append(plan, newSchemaChanges(file.Versions[versions[i]], false))
+		}
+		for i := sourceIndex; i > targetIndex; i-- {
+			plan = append(plan, newSchemaChanges(file.Versions[versions[i]], true))
+		}
+		m.plans[source] = plan
+	}
+
+	if targetAttribute != "" {
+		m.targetAttribute = targetAttribute
+		m.targets = make(map[string]*schemaMigrator, len(versions))
+		for _, version := range versions {
+			if version == target {
+				m.targets[version] = m
+				continue
+			}
+			other, err := newSchemaMigrator(logger, file, version, "", versionAttribute)
+			if err != nil {
+				return nil, err
+			}
+			m.targets[version] = other
+		}
+	}
+	return m, nil
+}
+
+// forResource returns the migrator to the resource's target version: the version in its
+// target version attribute when configured and present, the configured target otherwise.
+// It returns nil for a version the file does not list; that resource is left as it is.
+func (m *schemaMigrator) forResource(resource pcommon.Resource) *schemaMigrator {
+	if m.targetAttribute == "" {
+		return m
+	}
+	value, exists := resource.Attributes().Get(m.targetAttribute)
+	if !exists {
+		return m
+	}
+	if target, listed := m.targets[value.AsString()]; listed {
+		return target
+	}
+	m.reportInvalidTarget(value.AsString())
+	return nil
+}
+
+// reportInvalidTarget logs a per-resource target version the file does not list, once per version
+func (m *schemaMigrator) reportInvalidTarget(target string) {
+	m.reportedMu.Lock()
+	if m.invalidTargets[target] || len(m.invalidTargets) >= maxInvalidSchemaTargets {
+		m.reportedMu.Unlock()
+		return
+	}
+	m.invalidTargets[target] = true
+	m.reportedMu.Unlock()
+
+	m.logger.Warn("resource target schema version is not listed in the schema file, leaving its data unmigrated",
+		zap.String("attribute", m.targetAttribute),
+		zap.String("target_version", target))
+}
+
+// newSchemaChanges collects the renames of a version, inverted and in reverse order
+// for a downgrade
+func newSchemaChanges(version schemaFileVersion, downgrade bool) schemaChanges {
+	return schemaChanges{
+		all:       newSchemaRenames(version.All, downgrade),
+		resources: newSchemaRenames(version.Resources, downgrade),
+		spans:     newSchemaRenames(version.Spans, downgrade),
+		metrics:   newSchemaRenames(version.Metrics, downgrade),
+		logs:      newSchemaRenames(version.Logs, downgrade),
+		downgrade: downgrade,
+	}
+}
+
+// sections returns the all section and the section of the data in the order they apply.
+// An upgrade applies all first; a downgrade reverts the data's own section first.
+func (c schemaChanges) sections(own []schemaRenames) [][]schemaRenames {
+	if c.downgrade {
+		return [][]schemaRenames{own, c.all}
+	}
+	return [][]schemaRenames{c.all, own}
+}
+
+func newSchemaRenames(section schemaFileSection, downgrade bool) []schemaRenames {
+	var changes []schemaRenames
+	for _, change := range section.Changes {
+		if len(change.RenameMetrics) > 0 {
+			metricNames := make(map[string]string, len(change.RenameMetrics))
+			for from, to := range change.RenameMetrics {
+				if downgrade {
+					from, to = to, from
+				}
+				metricNames[from] = to
+			}
+			changes = append(changes, schemaRenames{metricNames: metricNames})
+		}
+		if change.RenameAttributes == nil {
+			continue
+		}
+
+		// Sorted for a deterministic result when renames touch the same attributes
+		renames := make([]attributeRename, 0, len(change.RenameAttributes.AttributeMap))
+		for from, to := range change.RenameAttributes.AttributeMap {
+			if downgrade {
+				from, to = to, from
+			}
+			renames = append(renames, attributeRename{From: from, To: to})
+		}
+		sort.Slice(renames, func(i, j int) bool { return renames[i].From < renames[j].From })
+
+		changes = append(changes, schemaRenames{
+			renames: renames,
+			spans:   nameSet(change.RenameAttributes.ApplyToSpans),
+			metrics: nameSet(change.RenameAttributes.ApplyToMetrics),
+		})
+	}
+
+	if downgrade {
+		for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+			changes[i], changes[j] = changes[j], changes[i]
+		}
+	}
+	return changes
+}
+
+// nameSet returns the names as a set, nil when there are none
+func nameSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// plan returns the steps from the version in schemaURL to the target; false when the
+// URL belongs to another schema family or names a version the file does not list.
+// Such a version cannot be migrated without guessing the changes that lead to it, so
+// its data is left as it is rather than partially migrated.
+func (m *schemaMigrator) plan(schemaURL string) ([]schemaChanges, bool) {
+	if !strings.HasPrefix(schemaURL, m.urlPrefix) {
+		return nil, false
+	}
+	source := schemaURL[len(m.urlPrefix):]
+	plan, ok := m.plans[source]
+	if !ok {
+		m.reportUnreachable(source)
+	}
+	return plan, ok
+}
+
+// reportUnreachable logs a source version the target cannot be reached from, once per version
+func (m *schemaMigrator) reportUnreachable(source string) {
+	m.reportedMu.Lock()
+	if m.unreachable[source] || len(m.unreachable) >= maxUnreachableSchemaVersions {
+		m.reportedMu.Unlock()
+		return
+	}
+	m.unreachable[source] = true
+	m.reportedMu.Unlock()
+
+	m.logger.Warn("schema version is not listed in the schema file, leaving its data unmigrated",
+		zap.String("source_version", source),
+		zap.String("target_version", m.target))
+}
+
+// schemaData is the kind of data renamed attributes belong to, which selects the
+// apply_to_spans or apply_to_metrics list limiting a change
+type schemaData int
+
+const (
+	schemaOtherData  schemaData = iota // Resources, span events and log records
+	schemaSpanData                     // Span attributes, limited by apply_to_spans
+	schemaMetricData                   // Data point attributes, limited by apply_to_metrics
+)
+
+// applySchemaRenames applies the changes that are not limited to other span or metric names
+func applySchemaRenames(attrs pcommon.Map, changes []schemaRenames, data schemaData, name string) {
+	for _, change := range changes {
+		change.apply(attrs, data, name)
+	}
+}
+
+// apply renames the attributes if the change applies to the named data
+func (c schemaRenames) apply(attrs pcommon.Map, data schemaData, name string) {
+	if c.appliesTo(data, name) {
+		renameAttributes(attrs, c.renames)
+	}
+}
+
+// appliesTo reports whether the change applies to the named span or metric. A change
+// limited to spans never applies to metrics and vice versa, and a limited change never
+// applies to other data.
+func (c schemaRenames) appliesTo(data schemaData, name string) bool {
+	switch data {
+	case schemaSpanData:
+		if c.spans != nil {
+			return c.spans[name]
+		}
+		return c.metrics == nil
+	case schemaMetricData:
+		if c.metrics != nil {
+			return c.metrics[name]
+		}
+		return c.spans == nil
+	default:
+		return c.spans == nil && c.metrics == nil
+	}
+}
+
+// renameMetric renames a metric unless another metric of its scope already has the new
+// name; names counts the metric names of the scope and is kept up to date
+func renameMetric(metric pmetric.Metric, renames map[string]string, names map[string]int) {
+	to, ok := renames[metric.Name()]
+	if !ok || names[to] > 0 {
+		return
+	}
+	names[metric.Name()]--
+	names[to]++
+	metric.SetName(to)
+}
+
+// migrateResource migrates the resource attributes, points its schema URL at the target
+// and records the target version when configured. Resources without a plan are left alone.
+func (m *schemaMigrator) migrateResource(resource pcommon.Resource, schemaURL string, setSchemaURL func(string)) {
+	plan, ok := m.plan(schemaURL)
+	if !ok {
+		return
+	}
+	for _, step := range plan {
+		for _, section := range step.sections(step.resources) {
+			applySchemaRenames(resource.Attributes(), section, schemaOtherData, "")
+		}
+	}
+	setSchemaURL(m.targetURL)
+	if m.versionAttribute != "" {
+		resource.Attributes().PutStr(m.versionAttribute, m.target)
+	}
+}
+
+// scopePlan returns the plan of a scope and points its schema URL at the target. A scope
+// without a schema URL of its own follows the resource's
+func (m *schemaMigrator) scopePlan(scopeURL, resourceURL string, setSchemaURL func(string)) ([]schemaChanges, bool) {
+	if scopeURL == "" {
+		return m.plan(resourceURL)
+	}
+	plan, ok := m.plan(scopeURL)
+	if ok {
+		setSchemaURL(m.targetURL)
+	}
+	return plan, ok
+}
+
+// migrateTraces migrates resource, span and span event attributes
+func (m *schemaMigrator) migrateTraces(td ptrace.Traces) {
+	resourceSpans := td.ResourceSpans()
+	for i := 0; i < resourceSpans.Len(); i++ {
+		rs := resourceSpans.At(i)
+		target := m.forResource(rs.Resource())
+		if target == nil {
+			continue
+		}
+		resourceURL := rs.SchemaUrl()
+		target.migrateResource(rs.Resource(), resourceURL, rs.SetSchemaUrl)
+
+		scopeSpans := rs.ScopeSpans()
+		for j := 0; j < scopeSpans.Len(); j++ {
+			ss := scopeSpans.At(j)
+			plan, ok := target.scopePlan(ss.SchemaUrl(), resourceURL, ss.SetSchemaUrl)
+			if !ok || len(plan) == 0 {
+				continue
+			}
+
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				for _, step := range plan {
+					for _, section := range step.sections(step.spans) {
+						applySchemaRenames(span.Attributes(), section, schemaSpanData, span.Name())
+					}
+					events := span.Events()
+					for e := 0; e < events.Len(); e++ {
+						applySchemaRenames(events.At(e).Attributes(), step.all, schemaOtherData, "")
+					}
+				}
+			}
+		}
+	}
+}
+
+// migrateMetrics migrates resource and data point attributes and metric names
+func (m *schemaMigrator) migrateMetrics(md pmetric.Metrics) {
+	resourceMetrics := md.ResourceMetrics()
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		rm := resourceMetrics.At(i)
+		target := m.forResource(rm.Resource())
+		if target == nil {
+			continue
+		}
+		resourceURL := rm.SchemaUrl()
+		target.migrateResource(rm.Resource(), resourceURL, rm.SetSchemaUrl)
+
+		scopeMetrics := rm.ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			sm := scopeMetrics.At(j)
+			plan, ok := target.scopePlan(sm.SchemaUrl(), resourceURL, sm.SetSchemaUrl)
+			if !ok || len(plan) == 0 {
+				continue
+			}
+
+			metrics := sm.Metrics()
+			names := make(map[string]int, metrics.Len())
+			for k := 0; k < metrics.Len(); k++ {
+				names[metrics.At(k).Name()]++
+			}
+
+			// Changes apply in order, so apply_to_metrics sees the names of its version
+			for _, step := range plan {
+				for k := 0; k < metrics.Len(); k++ {
+					metric := metrics.At(k)
+					for _, section := range step.sections(step.metrics) {
+						for _, change := range section {
+							if change.metricNames != nil {
+								renameMetric(metric, change.metricNames, names)
+								continue
+							}
+							forEachDataPointAttributes(metric, func(attrs pcommon.Map) {
+								change.apply(attrs, schemaMetricData, metric.Name())
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// migrateLogs migrates resource and log record attributes
+func (m *schemaMigrator) migrateLogs(ld plog.Logs) {
+	resourceLogs := ld.ResourceLogs()
+	for i := 0; i < resourceLogs.Len(); i++ {
+		rl := resourceLogs.At(i)
+		target := m.forResource(rl.Resource())
+		if target == nil {
+			continue
+		}
+		resourceURL := rl.SchemaUrl()
+		target.migrateResource(rl.Resource(), resourceURL, rl.SetSchemaUrl)
+
+		scopeLogs := rl.ScopeLogs()
+		for j := 0; j < scopeLogs.Len(); j++ {
+			sl := scopeLogs.At(j)
+			plan, ok := target.scopePlan(sl.SchemaUrl(), resourceURL, sl.SetSchemaUrl)
+			if !ok || len(plan) == 0 {
+				continue
+			}
+
+			records := sl.LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				for _, step := range plan {
+					for _, section := range step.sections(step.logs) {
+						applySchemaRenames(records.At(k).Attributes(), section, schemaOtherData, "")
+					}
+				}
+			}
+		}
+	}
+}
+
+// migrateProfiles migrates resource attributes. Profile samples keep their attributes in
+// the shared dictionary, which schema changes do not cover, so only resources are migrated
+func (m *schemaMigrator) migrateProfiles(pd pprofile.Profiles) {
+	resourceProfiles := pd.ResourceProfiles()
+	for i := 0; i < resourceProfiles.Len(); i++ {
+		rp := resourceProfiles.At(i)
+		if target := m.forResource(rp.Resource()); target != nil {
+			target.migrateResource(rp.Resource(), rp.SchemaUrl(), rp.SetSchemaUrl)
+		}
+	}
+}
+
+// forEachDataPointAttributes calls fn with the attributes of every data point of the metric
+func forEachDataPointAttributes(metric pmetric.Metric, fn func(pcommon.Map)) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			fn(dps.At(i).Attributes())
+		}
+	}
+}
@@ -0,0 +1,606 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// schemaFixture renames db.system in all.changes at 1.26.0, a metric attribute and
+// system.cpu.usage in metrics.changes at 1.26.0 and a span attribute in spans.changes at 1.25.0
+const schemaFixture = "testdata/schema/semconv.yaml"
+
+func TestLoadSchemaFile(t *testing.T) {
+	file, err := loadSchemaFile(schemaFixture)
+	require.NoError(t, err)
+	assert.Equal(t, "1.26.0", file.newestVersion())
+	assert.Equal(t, []string{"1.24.0", "1.25.0", "1.26.0"}, file.sortedVersions())
+
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "unsupported file format",
+			content: "file_format: 2.0.0\nschema_url: https://example.com/schemas/1.0.0\nversions:\n  1.0.0:\n",
+			errMsg:  `unsupported file_format "2.0.0"`,
+		},
+		{
+			name:    "invalid version",
+			content: "file_format: 1.1.0\nschema_url: https://example.com/schemas/1.0.0\nversions:\n  latest:\n",
+			errMsg:  `invalid schema version "latest"`,
+		},
+		{
+			name:    "no versions",
+			content: "file_format: 1.1.0\nschema_url: https://example.com/schemas/1.0.0\n",
+			errMsg:  "lists no versions",
+		},
+		{
+			name:    "schema url behind newest version",
+			content: "file_format: 1.1.0\nschema_url: https://example.com/schemas/1.0.0\nversions:\n  1.1.0:\n  1.0.0:\n",
+			errMsg:  `has schema_url version "1.0.0", but its newest version is "1.1.0"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "schema.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := loadSchemaFile(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	_, err = loadSchemaFile("testdata/schema/missing.yaml")
+	assert.ErrorContains(t, err, "failed to read schema file")
+}
+
+func TestNewSchemaRenames(t *testing.T) {
+	changes := newSchemaRenames(schemaFileSection{Changes: []schemaFileChange{
+		{RenameAttributes: &schemaFileRenameAttributes{
+			// A chain is one simultaneous substitution, so a never becomes c
+			AttributeMap:   map[string]string{"a": "b", "b": "c"},
+			ApplyToSpans:   []string{"publish"},
+			ApplyToMetrics: []string{"queue.depth"},
+		}},
+	}}, false)
+	require.Len(t, changes, 1)
+
+	tests := []struct {
+		name     string
+		data     schemaData
+		dataName string
+		expected map[string]any
+	}{
+		{
+			name:     "listed span",
+			data:     schemaSpanData,
+			dataName: "publish",
+			expected: map[string]any{"b": "1", "c": "2"},
+		},
+		{
+			name:     "metric name does not apply to spans",
+			data:     schemaSpanData,
+			dataName: "queue.depth",
+			expected: map[string]any{"a": "1", "b": "2"},
+		},
+		{
+			name:     "listed metric",
+			data:     schemaMetricData,
+			dataName: "queue.depth",
+			expected: map[string]any{"b": "1", "c": "2"},
+		},
+		{
+			name:     "span name does not apply to metrics",
+			data:     schemaMetricData,
+			dataName: "publish",
+			expected: map[string]any{"a": "1", "b": "2"},
+		},
+		{
+			name:     "limited change does not apply to other data",
+			data:     schemaOtherData,
+			expected: map[string]any{"a": "1", "b": "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			attrs.PutStr("a", "1")
+			attrs.PutStr("b", "2")
+
+			applySchemaRenames(attrs, changes, tt.data, tt.dataName)
+
+			assert.Equal(t, tt.expected, attrs.AsRaw())
+		})
+	}
+}
+
+func TestSchemaMigration_Traces(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: schemaFixture}})
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+	rs.Resource().Attributes().PutStr("db.system", "postgresql")
+
+	// The scope has no schema URL, so it follows the resource
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("publish")
+	span.Attributes().PutStr("db.system", "postgresql")
+	span.Attributes().PutInt("messaging.kafka.destination.partition", 3)
+	span.Events().AppendEmpty().Attributes().PutStr("db.system", "postgresql")
+
+	// A scope on an unknown version is left alone
+	other := rs.ScopeSpans().AppendEmpty()
+	other.SetSchemaUrl("https://opentelemetry.io/schemas/1.10.0")
+	otherSpan := other.Spans().AppendEmpty()
+	otherSpan.Attributes().PutStr("db.system", "mysql")
+
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"db.system.name": "postgresql"}, rs.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"db.system.name":                     "postgresql",
+		"messaging.destination.partition.id": int64(3),
+	}, span.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"db.system.name": "postgresql"}, span.Events().At(0).Attributes().AsRaw())
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.10.0", other.SchemaUrl())
+	assert.Equal(t, map[string]any{"db.system": "mysql"}, otherSpan.Attributes().AsRaw())
+}
+
+func TestSchemaMigration_Metrics(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: schemaFixture}})
+
+	metrics := pmetric.NewMetrics()
+	sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl("https://opentelemetry.io/schemas/1.25.0")
+
+	connections := sm.Metrics().AppendEmpty()
+	connections.SetName("db.client.connection.count")
+	connectionsPoint := connections.SetEmptySum().DataPoints().AppendEmpty()
+	connectionsPoint.Attributes().PutStr("state", "idle")
+	connectionsPoint.Attributes().PutStr("db.system", "postgresql")
+
+	// The metric-specific rename only applies to the listed metric
+	duration := sm.Metrics().AppendEmpty()
+	duration.SetName("db.client.operation.duration")
+	durationPoint := duration.SetEmptyHistogram().DataPoints().AppendEmpty()
+	durationPoint.Attributes().PutStr("state", "idle")
+	durationPoint.Attributes().PutStr("db.system", "postgresql")
+
+	_, err := processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", sm.SchemaUrl())
+	assert.Equal(t, map[string]any{
+		"db.client.connection.state": "idle",
+		"db.system.name":             "postgresql",
+	}, connectionsPoint.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{
+		"state":          "idle",
+		"db.system.name": "postgresql",
+	}, durationPoint.Attributes().AsRaw())
+}
+
+func TestSchemaMigration_Logs(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: schemaFixture}})
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.25.0")
+	record := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.Attributes().PutStr("db.system", "postgresql")
+
+	_, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", rl.SchemaUrl())
+	assert.Equal(t, map[string]any{"db.system.name": "postgresql"}, record.Attributes().AsRaw())
+}
+
+func TestSchemaMigration_Profiles(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: schemaFixture}})
+
+	profiles := pprofile.NewProfiles()
+	rp := profiles.ResourceProfiles().AppendEmpty()
+	rp.SetSchemaUrl("https://opentelemetry.io/schemas/1.25.0")
+	rp.Resource().Attributes().PutStr("db.system", "postgresql")
+
+	_, err := processor.processProfiles(context.Background(), profiles)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.26.0", rp.SchemaUrl())
+	assert.Equal(t, map[string]any{"db.system.name": "postgresql"}, rp.Resource().Attributes().AsRaw())
+}
+
+func TestSchemaMigration_Downgrade(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: schemaFixture, TargetVersion: "1.24.0"}})
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("db.system.name", "postgresql")
+	span.Attributes().PutInt("messaging.destination.partition.id", 3)
+
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.24.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]any{
+		"db.system":                             "postgresql",
+		"messaging.kafka.destination.partition": int64(3),
+	}, span.Attributes().AsRaw())
+}
+
+func TestSchemaMigration_DowngradeSectionOrder(t *testing.T) {
+	// 2.0.0 renames a to b for all data, and then b to c for metrics
+	path := filepath.Join(t.TempDir(), "schema.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`file_format: 1.1.0
+schema_url: https://example.com/schemas/2.0.0
+versions:
+  2.0.0:
+    all:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              a: b
+    metrics:
+      changes:
+        - rename_attributes:
+            attribute_map:
+              b: c
+  1.0.0:
+`), 0o600))
+
+	tests := []struct {
+		name     string
+		target   string
+		source   string
+		attr     string
+		expected string
+	}{
+		{name: "upgrade", source: "1.0.0", attr: "a", expected: "c"},
+		{name: "downgrade", target: "1.0.0", source: "2.0.0", attr: "c", expected: "a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: path, TargetVersion: tt.target}})
+
+			metrics := pmetric.NewMetrics()
+			sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+			sm.SetSchemaUrl("https://example.com/schemas/" + tt.source)
+			point := sm.Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+			point.Attributes().PutStr(tt.attr, "value")
+
+			_, err := processor.processMetrics(context.Background(), metrics)
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]any{tt.expected: "value"}, point.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestSchemaMigration_Reachability(t *testing.T) {
+	file, err := loadSchemaFile(schemaFixture)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		source     string
+		wantURL    string
+		wantAttrs  map[string]any
+		wantWarned bool
+	}{
+		{
+			name:      "reachable",
+			source:    "1.24.0",
+			wantURL:   "https://opentelemetry.io/schemas/1.26.0",
+			wantAttrs: map[string]any{"db.system.name": "postgresql"},
+		},
+		{
+			name:      "equal source and target",
+			source:    "1.26.0",
+			wantURL:   "https://opentelemetry.io/schemas/1.26.0",
+			wantAttrs: map[string]any{"db.system": "postgresql"},
+		},
+		{
+			name:       "newer than the schema file",
+			source:     "1.30.0",
+			wantURL:    "https://opentelemetry.io/schemas/1.30.0",
+			wantAttrs:  map[string]any{"db.system": "postgresql"},
+			wantWarned: true,
+		},
+		{
+			name:       "not listed between known versions",
+			source:     "1.25.5",
+			wantURL:    "https://opentelemetry.io/schemas/1.25.5",
+			wantAttrs:  map[string]any{"db.system": "postgresql"},
+			wantWarned: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.WarnLevel)
+			migrator, err := newSchemaMigrator(zap.New(core), file, "1.26.0", "", "")
+			require.NoError(t, err)
+
+			// Two batches, so an unreachable version is only reported once
+			var span ptrace.Span
+			var rs ptrace.ResourceSpans
+			for range 2 {
+				traces := ptrace.NewTraces()
+				rs = traces.ResourceSpans().AppendEmpty()
+				rs.SetSchemaUrl("https://opentelemetry.io/schemas/" + tt.source)
+				span = rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				span.Attributes().PutStr("db.system", "postgresql")
+				migrator.migrateTraces(traces)
+			}
+
+			assert.Equal(t, tt.wantURL, rs.SchemaUrl())
+			assert.Equal(t, tt.wantAttrs, span.Attributes().AsRaw())
+			if !tt.wantWarned {
+				assert.Zero(t, logs.Len())
+				return
+			}
+			require.Equal(t, 1, logs.Len())
+			assert.Equal(t, tt.source, logs.All()[0].ContextMap()["source_version"])
+			assert.Equal(t, "1.26.0", logs.All()[0].ContextMap()["target_version"])
+		})
+	}
+}
+
+func TestSchemaMigration_TargetVersionAttribute(t *testing.T) {
+	file, err := loadSchemaFile(schemaFixture)
+	require.NoError(t, err)
+	core, logs := observer.New(zap.WarnLevel)
+	migrator, err := newSchemaMigrator(zap.New(core), file, "1.26.0", "semconv.target_version", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		target    string // Resource attribute value, empty for none
+		source    string
+		attrs     map[string]any
+		wantURL   string
+		wantAttrs map[string]any
+	}{
+		{
+			name:      "resource target",
+			target:    "1.24.0",
+			source:    "1.26.0",
+			attrs:     map[string]any{"db.system.name": "postgresql"},
+			wantURL:   "https://opentelemetry.io/schemas/1.24.0",
+			wantAttrs: map[string]any{"db.system": "postgresql"},
+		},
+		{
+			name:      "default target",
+			source:    "1.24.0",
+			attrs:     map[string]any{"db.system": "postgresql"},
+			wantURL:   "https://opentelemetry.io/schemas/1.26.0",
+			wantAttrs: map[string]any{"db.system.name": "postgresql"},
+		},
+		{
+			name:      "invalid resource target",
+			target:    "9.9.9",
+			source:    "1.24.0",
+			attrs:     map[string]any{"db.system": "postgresql"},
+			wantURL:   "https://opentelemetry.io/schemas/1.24.0",
+			wantAttrs: map[string]any{"db.system": "postgresql"},
+		},
+	}
+
+	// Two batches, so an invalid target is only reported once
+	for range 2 {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				traces := ptrace.NewTraces()
+				rs := traces.ResourceSpans().AppendEmpty()
+				rs.SetSchemaUrl("https://opentelemetry.io/schemas/" + tt.source)
+				if tt.target != "" {
+					rs.Resource().Attributes().PutStr("semconv.target_version", tt.target)
+				}
+				span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				require.NoError(t, span.Attributes().FromRaw(tt.attrs))
+
+				migrator.migrateTraces(traces)
+
+				assert.Equal(t, tt.wantURL, rs.SchemaUrl())
+				assert.Equal(t, tt.wantAttrs, span.Attributes().AsRaw())
+			})
+		}
+	}
+
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "9.9.9", logs.All()[0].ContextMap()["target_version"])
+	assert.Equal(t, "semconv.target_version", logs.All()[0].ContextMap()["attribute"])
+}
+
+func TestSchemaMigration_TargetVersionAttributeConfig(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{
+		File:                   schemaFixture,
+		TargetVersionAttribute: "semconv.target_version",
+	}})
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.24.0")
+	rl.Resource().Attributes().PutStr("semconv.target_version", "1.25.0")
+	record := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.Attributes().PutStr("db.system", "postgresql")
+
+	_, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	// db.system is only renamed at 1.26.0, so migrating to 1.25.0 keeps it
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.25.0", rl.SchemaUrl())
+	assert.Equal(t, map[string]any{"db.system": "postgresql"}, record.Attributes().AsRaw())
+}
+
+func TestSchemaMigration_VersionAttribute(t *testing.T) {
+	tests := []struct {
+		name        string
+		migration   SchemaMigrationConfig
+		schemaURL   string
+		target      string // semconv.target_version resource attribute, empty for none
+		wantVersion string // Empty when semconv.version must be absent
+	}{
+		{
+			name:        "migrated to the configured target",
+			migration:   SchemaMigrationConfig{File: schemaFixture, VersionAttribute: "semconv.version"},
+			schemaURL:   "https://opentelemetry.io/schemas/1.24.0",
+			wantVersion: "1.26.0",
+		},
+		{
+			name: "migrated to the resource's target",
+			migration: SchemaMigrationConfig{
+				File:                   schemaFixture,
+				TargetVersionAttribute: "semconv.target_version",
+				VersionAttribute:       "semconv.version",
+			},
+			schemaURL:   "https://opentelemetry.io/schemas/1.26.0",
+			target:      "1.24.0",
+			wantVersion: "1.24.0",
+		},
+		{
+			name:      "no plan for an unlisted version",
+			migration: SchemaMigrationConfig{File: schemaFixture, VersionAttribute: "semconv.version"},
+			schemaURL: "https://opentelemetry.io/schemas/1.10.0",
+		},
+		{
+			name:      "no plan without a schema URL",
+			migration: SchemaMigrationConfig{File: schemaFixture, VersionAttribute: "semconv.version"},
+		},
+		{
+			name:      "not configured",
+			migration: SchemaMigrationConfig{File: schemaFixture},
+			schemaURL: "https://opentelemetry.io/schemas/1.24.0",
+		},
+		{
+			name:      "migration disabled",
+			migration: SchemaMigrationConfig{VersionAttribute: "semconv.version"},
+			schemaURL: "https://opentelemetry.io/schemas/1.24.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: tt.migration})
+
+			traces := ptrace.NewTraces()
+			rs := traces.ResourceSpans().AppendEmpty()
+			rs.SetSchemaUrl(tt.schemaURL)
+			if tt.target != "" {
+				rs.Resource().Attributes().PutStr("semconv.target_version", tt.target)
+			}
+			rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("publish")
+
+			_, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			version, exists := rs.Resource().Attributes().Get("semconv.version")
+			if tt.wantVersion == "" {
+				assert.False(t, exists)
+				return
+			}
+			require.True(t, exists)
+			assert.Equal(t, tt.wantVersion, version.Str())
+		})
+	}
+}
+
+func TestSchemaMigration_OtherSchemaFamily(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: schemaFixture}})
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://example.com/schemas/1.24.0")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("db.system", "postgresql")
+
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/schemas/1.24.0", rs.SchemaUrl())
+	assert.Equal(t, map[string]any{"db.system": "postgresql"}, span.Attributes().AsRaw())
+}
+
+func TestSchemaMigration_RenameMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		source   string
+		metrics  []string
+		expected []string
+	}{
+		{
+			name:     "upgrade",
+			source:   "1.25.0",
+			metrics:  []string{"system.cpu.usage", "system.memory.usage"},
+			expected: []string{"system.cpu.utilization", "system.memory.usage"},
+		},
+		{
+			name:     "downgrade",
+			target:   "1.24.0",
+			source:   "1.26.0",
+			metrics:  []string{"system.cpu.utilization"},
+			expected: []string{"system.cpu.usage"},
+		},
+		{
+			name:     "already at the target",
+			source:   "1.26.0",
+			metrics:  []string{"system.cpu.usage"},
+			expected: []string{"system.cpu.usage"},
+		},
+		{
+			name:     "new name already taken in the scope",
+			source:   "1.25.0",
+			metrics:  []string{"system.cpu.usage", "system.cpu.utilization"},
+			expected: []string{"system.cpu.usage", "system.cpu.utilization"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, _ := newTestProcessor(t, &Config{Enabled: true, SchemaMigration: SchemaMigrationConfig{File: schemaFixture, TargetVersion: tt.target}})
+
+			metrics := pmetric.NewMetrics()
+			sm := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+			sm.SetSchemaUrl("https://opentelemetry.io/schemas/" + tt.source)
+			for _, name := range tt.metrics {
+				metric := sm.Metrics().AppendEmpty()
+				metric.SetName(name)
+				metric.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(0.5)
+			}
+
+			_, err := processor.processMetrics(context.Background(), metrics)
+			require.NoError(t, err)
+
+			var names []string
+			for i := 0; i < sm.Metrics().Len(); i++ {
+				names = append(names, sm.Metrics().At(i).Name())
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
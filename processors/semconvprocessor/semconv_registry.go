@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"bufio"
+	"embed"
+	"sort"
+	"strings"
+)
+
+// maxUnknownAttributeKeys bounds the number of distinct keys reported by the
+// unknown attributes metric; further keys are reported as otherAttributeKey
+const maxUnknownAttributeKeys = 100
+
+// otherAttributeKey is reported once maxUnknownAttributeKeys distinct keys have been seen
+const otherAttributeKey = "_other"
+
+// knownSemconvAttributePrefixes lists template attributes whose key ends in a
+// user-defined suffix, such as http.request.header.<name>
+var knownSemconvAttributePrefixes = []string{
+	"http.request.header.",
+	"http.response.header.",
+	"rpc.grpc.request.metadata.",
+	"rpc.grpc.response.metadata.",
+	"db.query.parameter.",
+	"db.operation.parameter.",
+}
+
+// registryFiles holds the attribute names of each supported semconv version. Each
+// file lists the attributes added in its version; deprecated attributes stay listed
+// in the registry, so later versions only add names.
+//
+//go:embed registry/*.txt
+var registryFiles embed.FS
+
+// semconvRegistries maps a supported semconv version to the attributes it defines
+var semconvRegistries = loadSemconvRegistries()
+
+// latestSemconvVersion is the newest embedded registry, used when no semconv_version is set
+var latestSemconvVersion = supportedSemconvVersions()[len(semconvRegistries)-1]
+
+// loadSemconvRegistries builds each version's registry from its file and those of all earlier versions
+func loadSemconvRegistries() map[string]map[string]bool {
+	entries, err := registryFiles.ReadDir("registry")
+	if err != nil {
+		panic(err)
+	}
+
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		versions = append(versions, strings.TrimSuffix(entry.Name(), ".txt"))
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+
+	registries := make(map[string]map[string]bool, len(versions))
+	known := make(map[string]bool)
+	for _, version := range versions {
+		file, err := registryFiles.Open("registry/" + version + ".txt")
+		if err != nil {
+			panic(err)
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				known[line] = true
+			}
+		}
+		_ = file.Close()
+
+		registry := make(map[string]bool, len(known))
+		for key := range known {
+			registry[key] = true
+		}
+		registries[version] = registry
+	}
+	return registries
+}
+
+// compareVersions orders major.minor version strings numerically
+func compareVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if len(aParts[i]) != len(bParts[i]) {
+			return len(aParts[i]) - len(bParts[i])
+		}
+		if aParts[i] != bParts[i] {
+			return strings.Compare(aParts[i], bParts[i])
+		}
+	}
+	return len(aParts) - len(bParts)
+}
+
+// supportedSemconvVersions returns the versions with an embedded registry, in order
+func supportedSemconvVersions() []string {
+	versions := make([]string, 0, len(semconvRegistries))
+	for version := range semconvRegistries {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i], versions[j]) < 0
+	})
+	return versions
+}
+
+// isKnownAttribute reports whether key is defined by the given semconv version. An
+// empty version uses the newest embedded registry, which includes every earlier one.
+func isKnownAttribute(key, version string) bool {
+	if version == "" {
+		version = latestSemconvVersion
+	}
+	if semconvRegistries[version][key] {
+		return true
+	}
+	for _, prefix := range knownSemconvAttributePrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsKnownAttribute(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		version  string
+		expected bool
+	}{
+		{name: "1.27 attribute in 1.27", key: "messaging.consumer.group.name", version: "1.27", expected: true},
+		{name: "1.27 attribute in 1.24", key: "messaging.consumer.group.name", version: "1.24", expected: false},
+		{name: "1.26 attribute inherited by 1.27", key: "db.query.text", version: "1.27", expected: true},
+		{name: "1.24 attribute inherited by 1.27", key: "http.request.method", version: "1.27", expected: true},
+		{name: "deprecated attribute stays known", key: "http.method", version: "1.27", expected: true},
+		{name: "unknown attribute", key: "customer_id", version: "1.27", expected: false},
+		{name: "template attribute", key: "http.request.header.x-request-id", version: "1.24", expected: true},
+		{name: "newest registry without version", key: "db.system.name", expected: true},
+		{name: "1.30 attribute in 1.27", key: "db.system.name", version: "1.27", expected: false},
+		{name: "1.32 attribute without version", key: "db.stored_procedure.name", expected: true},
+		{name: "unknown attribute without version", key: "customer_id", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isKnownAttribute(tt.key, tt.version))
+		})
+	}
+}
+
+func TestSupportedSemconvVersions(t *testing.T) {
+	assert.Equal(t, []string{"1.24", "1.26", "1.27", "1.30", "1.32"}, supportedSemconvVersions())
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Negative(t, compareVersions("1.9", "1.24"))
+	assert.Negative(t, compareVersions("1.26", "1.27"))
+	assert.Positive(t, compareVersions("2.0", "1.27"))
+	assert.Zero(t, compareVersions("1.27", "1.27"))
+}
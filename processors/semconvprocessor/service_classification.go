@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// serviceClassifier derives a label such as a service tier from a service name
+type serviceClassifier struct {
+	rules     []compiledServiceClass
+	attribute string      // Resource attribute the label is written to
+	fallback  string      // Label of services no rule matches
+	regexes   *regexGuard // Skips oversized service names, which get the fallback
+}
+
+// compiledServiceClass is a service_classification rule with its pattern compiled
+type compiledServiceClass struct {
+	pattern *regexp.Regexp
+	label   string
+}
+
+// newServiceClassifier compiles the rules of a validated configuration
+func newServiceClassifier(cfg ServiceClassificationConfig, regexes *regexGuard) *serviceClassifier {
+	c := &serviceClassifier{
+		rules:     make([]compiledServiceClass, 0, len(cfg.Rules)),
+		attribute: cfg.Attribute,
+		fallback:  cfg.Default,
+		regexes:   regexes,
+	}
+	for _, rule := range cfg.Rules {
+		c.rules = append(c.rules, compiledServiceClass{
+			pattern: regexp.MustCompile(rule.Pattern),
+			label:   rule.Label,
+		})
+	}
+	return c
+}
+
+// classify returns the label of the first rule matching the service name, or the fallback
+func (c *serviceClassifier) classify(ctx context.Context, serviceName string) string {
+	if c.regexes.allow(ctx, serviceName) {
+		for _, rule := range c.rules {
+			if rule.pattern.MatchString(serviceName) {
+				return rule.label
+			}
+		}
+	}
+	return c.fallback
+}
+
+// classifyResource writes the label of the resource's service.name, unless the
+// resource already carries the attribute. Resources without a service name get the fallback.
+func (c *serviceClassifier) classifyResource(ctx context.Context, resource pcommon.Resource) {
+	attrs := resource.Attributes()
+	if _, exists := attrs.Get(c.attribute); exists {
+		return
+	}
+
+	var serviceName string
+	if value, ok := attrs.Get("service.name"); ok {
+		serviceName = value.AsString()
+	}
+	attrs.PutStr(c.attribute, c.classify(ctx, serviceName))
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// tierRules labels payment services critical and anything internal as internal
+var tierRules = []ServiceClassificationRule{
+	{Pattern: "^payments-", Label: "critical"},
+	{Pattern: "^internal-|-admin$", Label: "internal"},
+}
+
+func TestServiceClassification(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled:               true,
+		ServiceClassification: ServiceClassificationConfig{Rules: tierRules},
+	})
+
+	tests := []struct {
+		name     string
+		resource map[string]any
+		expected string
+	}{
+		{
+			name:     "first matching rule",
+			resource: map[string]any{"service.name": "payments-api"},
+			expected: "critical",
+		},
+		{
+			name:     "second rule",
+			resource: map[string]any{"service.name": "billing-admin"},
+			expected: "internal",
+		},
+		{
+			name:     "no rule matches",
+			resource: map[string]any{"service.name": "checkout"},
+			expected: "unknown",
+		},
+		{
+			name:     "no service name",
+			resource: map[string]any{"host.name": "node-1"},
+			expected: "unknown",
+		},
+		{
+			name:     "existing label is kept",
+			resource: map[string]any{"service.name": "payments-api", "service.tier": "gold"},
+			expected: "gold",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traces := ptrace.NewTraces()
+			resource := traces.ResourceSpans().AppendEmpty().Resource()
+			require.NoError(t, resource.Attributes().FromRaw(tt.resource))
+
+			_, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			tier, exists := resource.Attributes().Get("service.tier")
+			require.True(t, exists)
+			assert.Equal(t, tt.expected, tier.Str())
+		})
+	}
+}
+
+func TestServiceClassification_CustomAttributeAndDefault(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled: true,
+		ServiceClassification: ServiceClassificationConfig{
+			Attribute: "service.criticality",
+			Default:   "low",
+			Rules:     tierRules,
+		},
+	})
+
+	logs := plog.NewLogs()
+	critical := logs.ResourceLogs().AppendEmpty().Resource()
+	critical.Attributes().PutStr("service.name", "payments-ledger")
+	other := logs.ResourceLogs().AppendEmpty().Resource()
+	other.Attributes().PutStr("service.name", "search")
+
+	_, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"service.name": "payments-ledger", "service.criticality": "critical"}, critical.Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"service.name": "search", "service.criticality": "low"}, other.Attributes().AsRaw())
+}
+
+func TestServiceClassification_Disabled(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{Enabled: true})
+
+	traces := ptrace.NewTraces()
+	resource := traces.ResourceSpans().AppendEmpty().Resource()
+	resource.Attributes().PutStr("service.name", "payments-api")
+
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"service.name": "payments-api"}, resource.Attributes().AsRaw())
+}
+
+func TestProcessTraces_ClassifyServiceInRule(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled:               true,
+		ServiceClassification: ServiceClassificationConfig{Rules: tierRules},
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			Rules: []OTTLRule{
+				{
+					ID:            "tiered",
+					Condition:     `resource.attributes["service.name"] != nil`,
+					OperationName: `Concat([ClassifyService(resource.attributes["service.name"]), name], " ")`,
+				},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "internal-jobs")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("cleanup")
+
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	operationName, exists := span.Attributes().Get("operation.name")
+	require.True(t, exists)
+	assert.Equal(t, "internal cleanup", operationName.Str())
+}
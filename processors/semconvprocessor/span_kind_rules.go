@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// spanKindsByName maps the kind names accepted in span_kind_rules to span kinds
+var spanKindsByName = map[string]ptrace.SpanKind{
+	"server":   ptrace.SpanKindServer,
+	"client":   ptrace.SpanKindClient,
+	"producer": ptrace.SpanKindProducer,
+	"consumer": ptrace.SpanKindConsumer,
+	"internal": ptrace.SpanKindInternal,
+}
+
+// compiledSpanKindRule is a span_kind_rules entry with its condition parsed
+type compiledSpanKindRule struct {
+	condition *ottl.Condition[ottlspan.TransformContext]
+	kind      ptrace.SpanKind
+}
+
+// compileSpanKindRules parses the span kind rules, keeping their order
+func (sp *semconvProcessor) compileSpanKindRules() error {
+	for i, rule := range sp.config.SpanProcessing.SpanKindRules {
+		condition, err := sp.parser.ParseCondition(rule.Condition)
+		if err != nil {
+			return fmt.Errorf("failed to parse span_kind_rules[%d]: %w", i, err)
+		}
+		sp.spanKindRules = append(sp.spanKindRules, compiledSpanKindRule{
+			condition: condition,
+			kind:      spanKindsByName[rule.Kind],
+		})
+	}
+	return nil
+}
+
+// applySpanKindRules sets the kind of the first matching span kind rule. Spans with
+// an explicit kind other than internal are only changed with override_span_kind, and
+// audit mode leaves every span untouched. It reports whether the kind changed.
+func (sp *semconvProcessor) applySpanKindRules(ctx context.Context, span ptrace.Span, resource pcommon.Resource, scope pcommon.InstrumentationScope) bool {
+	if len(sp.spanKindRules) == 0 || sp.config.SpanProcessing.Mode == ModeAudit {
+		return false
+	}
+	if kind := span.Kind(); kind != ptrace.SpanKindInternal && kind != ptrace.SpanKindUnspecified && !sp.config.SpanProcessing.OverrideSpanKind {
+		return false
+	}
+
+	tCtx := ottlspan.NewTransformContext(span, scope, resource, ptrace.NewScopeSpans(), ptrace.NewResourceSpans())
+	for _, rule := range sp.spanKindRules {
+		matches, err := rule.condition.Eval(ctx, tCtx)
+		if err != nil {
+			sp.logger.Debug("span kind rule evaluation error", zap.Error(err))
+			continue
+		}
+		if matches {
+			changed := span.Kind() != rule.kind
+			span.SetKind(rule.kind)
+			return changed
+		}
+	}
+	return false
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// httpSpanKindRules infer server spans from a route and client spans from a peer address
+var httpSpanKindRules = []SpanKindRule{
+	{Condition: `attributes["http.request.method"] != nil and attributes["http.route"] != nil`, Kind: "server"},
+	{Condition: `attributes["http.request.method"] != nil and attributes["server.address"] != nil`, Kind: "client"},
+}
+
+func TestSpanKindRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         ProcessingMode
+		override     bool
+		kind         ptrace.SpanKind
+		attributes   map[string]any
+		expectedKind ptrace.SpanKind
+	}{
+		{
+			name:         "internal span inferred as server",
+			kind:         ptrace.SpanKindInternal,
+			attributes:   map[string]any{"http.request.method": "GET", "http.route": "/users/{id}"},
+			expectedKind: ptrace.SpanKindServer,
+		},
+		{
+			name:         "unspecified span inferred as client",
+			kind:         ptrace.SpanKindUnspecified,
+			attributes:   map[string]any{"http.request.method": "GET", "server.address": "api.example.com"},
+			expectedKind: ptrace.SpanKindClient,
+		},
+		{
+			name:         "no rule matches",
+			kind:         ptrace.SpanKindInternal,
+			attributes:   map[string]any{"db.system.name": "postgresql"},
+			expectedKind: ptrace.SpanKindInternal,
+		},
+		{
+			name:         "explicit kind is preserved",
+			kind:         ptrace.SpanKindConsumer,
+			attributes:   map[string]any{"http.request.method": "GET", "http.route": "/users/{id}"},
+			expectedKind: ptrace.SpanKindConsumer,
+		},
+		{
+			name:         "explicit kind is overridden when configured",
+			override:     true,
+			kind:         ptrace.SpanKindConsumer,
+			attributes:   map[string]any{"http.request.method": "GET", "http.route": "/users/{id}"},
+			expectedKind: ptrace.SpanKindServer,
+		},
+		{
+			name:         "audit mode leaves the kind alone",
+			mode:         ModeAudit,
+			kind:         ptrace.SpanKindInternal,
+			attributes:   map[string]any{"http.request.method": "GET", "http.route": "/users/{id}"},
+			expectedKind: ptrace.SpanKindInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			processor, _ := newTestProcessor(t, &Config{
+				Enabled: true,
+				SpanProcessing: SpanProcessingConfig{
+					Enabled:          true,
+					Mode:             tt.mode,
+					SpanKindRules:    httpSpanKindRules,
+					OverrideSpanKind: tt.override,
+					Rules: []OTTLRule{
+						{
+							// Relies on the corrected kind
+							ID:            "http_server",
+							Priority:      100,
+							SpanKind:      []string{"server"},
+							Condition:     `attributes["http.route"] != nil`,
+							OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+						},
+					},
+				},
+			})
+
+			traces := ptrace.NewTraces()
+			span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+			span.SetName("handler")
+			span.SetKind(tt.kind)
+			require.NoError(t, span.Attributes().FromRaw(tt.attributes))
+
+			_, err := processor.processTraces(context.Background(), traces)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedKind, span.Kind())
+		})
+	}
+}
+
+func TestSpanKindRules_RulesSeeCorrectedKind(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:       true,
+			Mode:          ModeEnrich,
+			SpanKindRules: httpSpanKindRules,
+			Rules: []OTTLRule{
+				{
+					// Relies on the corrected kind
+					ID:            "http_server",
+					Priority:      100,
+					SpanKind:      []string{"server"},
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+				},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("handler")
+	span.SetKind(ptrace.SpanKindInternal)
+	span.Attributes().PutStr("http.request.method", "GET")
+	span.Attributes().PutStr("http.route", "/users/{id}")
+
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// The rule is restricted to server spans, so it only matches after the correction
+	operationName, exists := span.Attributes().Get("operation.name")
+	require.True(t, exists)
+	assert.Equal(t, "GET /users/{id}", operationName.Str())
+}
+
+func TestSpanKindRules_StampProvenance(t *testing.T) {
+	processor, _ := newTestProcessor(t, &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled:         true,
+			Mode:            ModeEnforce,
+			SpanKindRules:   httpSpanKindRules,
+			StampProvenance: true,
+			Rules: []OTTLRule{
+				{
+					// Relies on the corrected kind
+					ID:            "http_server",
+					Priority:      100,
+					SpanKind:      []string{"server"},
+					Condition:     `attributes["http.route"] != nil`,
+					OperationName: `Concat([attributes["http.request.method"], attributes["http.route"]], " ")`,
+				},
+			},
+		},
+	})
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	corrected := spans.AppendEmpty()
+	corrected.SetName("GET")
+	corrected.SetKind(ptrace.SpanKindInternal)
+	corrected.Attributes().PutStr("http.request.method", "GET")
+	corrected.Attributes().PutStr("server.address", "api.example.com")
+	alreadyClient := spans.AppendEmpty()
+	alreadyClient.SetName("GET")
+	alreadyClient.SetKind(ptrace.SpanKindClient)
+	alreadyClient.Attributes().PutStr("http.request.method", "GET")
+	alreadyClient.Attributes().PutStr("server.address", "api.example.com")
+
+	_, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	// Only the kind of the first span changes, and no rule matches either span
+	assert.Equal(t, ptrace.SpanKindClient, corrected.Kind())
+	_, exists := corrected.Attributes().Get(provenanceProcessedAttribute)
+	assert.True(t, exists)
+	_, exists = alreadyClient.Attributes().Get(provenanceProcessedAttribute)
+	assert.False(t, exists)
+}
@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// dedupSynonyms removes synonym attributes that duplicate their canonical attribute.
+// A synonym whose value differs from the canonical one is kept and logged, since
+// dropping it would lose data. It reports whether any synonym was removed.
+func (sp *semconvProcessor) dedupSynonyms(attrs pcommon.Map) bool {
+	modified := false
+	for _, set := range sp.config.SpanProcessing.AttributeSynonyms {
+		canonical, exists := attrs.Get(set.Canonical)
+		if !exists {
+			continue
+		}
+		for _, synonym := range set.Synonyms {
+			value, exists := attrs.Get(synonym)
+			if !exists {
+				continue
+			}
+			if !value.Equal(canonical) {
+				sp.logger.Warn("synonym attribute conflicts with its canonical attribute, keeping both",
+					zap.String("canonical", set.Canonical),
+					zap.String("synonym", synonym))
+				continue
+			}
+			attrs.Remove(synonym)
+			modified = true
+		}
+	}
+	return modified
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/cedricziel/semconvprocessor/processors/semconvprocessor/internal/metadata"
+)
+
+func TestProcessTraces_AttributeSynonyms(t *testing.T) {
+	cfg := &Config{
+		Enabled: true,
+		SpanProcessing: SpanProcessingConfig{
+			Enabled: true,
+			Mode:    ModeEnrich,
+			AttributeSynonyms: []AttributeSynonyms{
+				{Canonical: "http.request.method", Synonyms: []string{"http.method"}},
+				{Canonical: "url.full", Synonyms: []string{"http.url"}},
+			},
+			Rules: []OTTLRule{
+				{
+					ID:            "test",
+					Priority:      100,
+					Condition:     `true`,
+					OperationName: `"test"`,
+				},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	core, logs := observer.New(zap.WarnLevel)
+	telemetryBuilder, _ := metadata.NewTelemetryBuilder(processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	processor, err := newSemconvProcessor(zap.New(core), cfg, telemetryBuilder, processortest.NewNopSettings(component.MustNewType("semconv")).TelemetrySettings)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("request")
+	span.Attributes().PutStr("http.request.method", "GET")
+	span.Attributes().PutStr("http.method", "GET")
+	span.Attributes().PutStr("url.full", "https://example.com/users")
+	span.Attributes().PutStr("http.url", "https://example.com/orders")
+
+	result, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+
+	attrs := result.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+
+	// Equal values: the synonym is dropped
+	_, exists := attrs.Get("http.method")
+	assert.False(t, exists)
+	method, _ := attrs.Get("http.request.method")
+	assert.Equal(t, "GET", method.Str())
+
+	// Conflicting values: both are kept and the conflict is logged
+	_, exists = attrs.Get("http.url")
+	assert.True(t, exists)
+	_, exists = attrs.Get("url.full")
+	assert.True(t, exists)
+
+	warnings := logs.FilterMessage("synonym attribute conflicts with its canonical attribute, keeping both").All()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "http.url", warnings[0].ContextMap()["synonym"])
+}